@@ -22,3 +22,11 @@ type Calc interface {
 	// drawing (0% = bad, 100% = well matched).
 	CalcMatchQual(gi *GameInfo, teams []Team) float64
 }
+
+// Error returning versions of the Calc methods. Calculators that implement
+// TryCalc report invalid input as one of the error types in this package
+// instead of panicking.
+type TryCalc interface {
+	TryCalcNewRatings(gi *GameInfo, priors []Team, teamRanks ...int) (PlayerRatings, error)
+	TryCalcMatchQual(gi *GameInfo, teams []Team) (float64, error)
+}
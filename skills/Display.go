@@ -0,0 +1,102 @@
+package skills
+
+import (
+	"math"
+)
+
+// The number of standard deviations the original TrueSkill implementation
+// subtracts from the mean for a conservative rating.
+const DefaultConservativeMultiplier = 3
+
+// Returns a conservative estimate of the skill, the mean less
+// DefaultConservativeMultiplier standard deviations. The player is very
+// likely at least this good.
+func (r Rating) ConservativeRating() float64 {
+	return r.Exposure(DefaultConservativeMultiplier)
+}
+
+// Returns the mean less k standard deviations.
+func (r Rating) Exposure(k float64) float64 {
+	return r.mean - k*r.stddev
+}
+
+// Maps ratings to the numbers shown to players, so every screen that shows a
+// rating shows the same number. A rating is displayed as
+//
+//	Offset + Scale*(mean - K*stddev)
+//
+// rounded to the nearest multiple of Round and then clamped to [Min, Max].
+type DisplayScale struct {
+	K      float64 // standard deviations subtracted from the mean
+	Scale  float64 // display points per unit of skill; must not be 0
+	Offset float64 // display value of a conservative skill of zero
+	Round  float64 // step to round to; 0 means no rounding
+	Clamp  bool    // whether to clamp to [Min, Max]
+	Min    float64
+	Max    float64
+}
+
+// Returns the scale Xbox Live used for TrueSkill with DefaultGameInfo():
+// conservative ratings from 0 to 50 in whole numbers.
+func TrueSkillDisplayScale() *DisplayScale {
+	return &DisplayScale{K: DefaultConservativeMultiplier, Scale: 1, Round: 1, Clamp: true, Min: 0, Max: 50}
+}
+
+// Returns a scale that shows DefaultGameInfo() ratings the way chess players
+// are used to: whole numbers from 0 to 3000.
+func EloDisplayScale() *DisplayScale {
+	return &DisplayScale{K: DefaultConservativeMultiplier, Scale: 60, Round: 1, Clamp: true, Min: 0, Max: 3000}
+}
+
+// Returns the unscaled conservative skill of r, its mean less K standard
+// deviations.
+func (d *DisplayScale) Exposure(r Rating) float64 {
+	return r.Exposure(d.K)
+}
+
+// Returns the number to show for r.
+func (d *DisplayScale) Display(r Rating) float64 {
+	v := d.Offset + d.Scale*d.Exposure(r)
+	if d.Round != 0 {
+		v = math.Round(v/d.Round) * d.Round
+	}
+	if d.Clamp {
+		v = math.Max(d.Min, math.Min(d.Max, v))
+	}
+	return v
+}
+
+// Validate returns a *DisplayScaleError for the first field of d that can't
+// map ratings to display values and back, or nil if d is valid.
+func (d *DisplayScale) Validate() error {
+	switch {
+	case !isFinite(d.K):
+		return &DisplayScaleError{"K", d.K}
+	case d.Scale == 0 || !isFinite(d.Scale):
+		return &DisplayScaleError{"Scale", d.Scale}
+	case !isFinite(d.Offset):
+		return &DisplayScaleError{"Offset", d.Offset}
+	case !(d.Round >= 0) || !isFinite(d.Round):
+		return &DisplayScaleError{"Round", d.Round}
+	case d.Clamp && !(d.Min <= d.Max):
+		return &DisplayScaleError{"Min", d.Min}
+	}
+	return nil
+}
+
+// Returns the conservative skill that display stands for. It inverts
+// Display up to rounding and clamping, which lose information. It panics if
+// d isn't valid.
+func (d *DisplayScale) Skill(display float64) float64 {
+	if err := d.Validate(); err != nil {
+		panic(err)
+	}
+	return (display - d.Offset) / d.Scale
+}
+
+// Returns the rating with the given standard deviation that is displayed as
+// display, before rounding and clamping. It is useful for seeding players at
+// a displayed value, such as an imported rating. It panics if d isn't valid.
+func (d *DisplayScale) Rating(display, stddev float64) Rating {
+	return NewRating(d.Skill(display)+d.K*stddev, stddev)
+}
@@ -0,0 +1,91 @@
+package skills
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestConservativeRating(t *testing.T) {
+	r := NewRating(30, 2)
+	if got := r.ConservativeRating(); got != 24 {
+		t.Errorf("ConservativeRating() = %v, want 24", got)
+	}
+	if got := r.Exposure(2.5); got != 25 {
+		t.Errorf("Exposure(2.5) = %v, want 25", got)
+	}
+	if got := DefaultGameInfo().DefaultRating().ConservativeRating(); math.Abs(got) > 1e-12 {
+		t.Errorf("default ConservativeRating() = %v, want 0", got)
+	}
+}
+
+func TestDisplayScale(t *testing.T) {
+	for _, tc := range []struct {
+		scale *DisplayScale
+		r     Rating
+		want  float64
+	}{
+		{TrueSkillDisplayScale(), NewRating(30, 2), 24},
+		{TrueSkillDisplayScale(), NewRating(30.4, 2.1), 24},
+		{TrueSkillDisplayScale(), NewRating(20, 8), 0},
+		{TrueSkillDisplayScale(), NewRating(70, 1), 50},
+		{EloDisplayScale(), NewRating(30, 2), 1440},
+		{EloDisplayScale(), NewRating(80, 1), 3000},
+		{&DisplayScale{K: 2, Scale: 100, Offset: 1000, Round: 50}, NewRating(10.3, 1), 1850},
+		{&DisplayScale{K: 0, Scale: 1}, NewRating(12.345, 3), 12.345},
+	} {
+		if got := tc.scale.Display(tc.r); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("%+v.Display(%v) = %v, want %v", *tc.scale, tc.r, got, tc.want)
+		}
+	}
+}
+
+func TestDisplayScaleInverse(t *testing.T) {
+	d := &DisplayScale{K: 3, Scale: 60, Offset: 100}
+
+	r := NewRating(31.5, 2.5)
+	if got := d.Skill(d.Display(r)); math.Abs(got-r.ConservativeRating()) > 1e-9 {
+		t.Errorf("Skill(Display(r)) = %v, want %v", got, r.ConservativeRating())
+	}
+
+	seeded := d.Rating(1500, 4)
+	if got := d.Display(seeded); math.Abs(got-1500) > 1e-9 {
+		t.Errorf("Display(Rating(1500, 4)) = %v, want 1500", got)
+	}
+	if seeded.Stddev() != 4 {
+		t.Errorf("Rating(1500, 4).Stddev() = %v, want 4", seeded.Stddev())
+	}
+}
+
+func TestDisplayScaleValidate(t *testing.T) {
+	for _, d := range []*DisplayScale{TrueSkillDisplayScale(), EloDisplayScale(), {Scale: -1}} {
+		if err := d.Validate(); err != nil {
+			t.Errorf("%+v.Validate() = %v, want nil", *d, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		scale *DisplayScale
+		field string
+	}{
+		{&DisplayScale{}, "Scale"},
+		{&DisplayScale{Scale: math.NaN()}, "Scale"},
+		{&DisplayScale{Scale: math.Inf(1)}, "Scale"},
+		{&DisplayScale{K: math.NaN(), Scale: 1}, "K"},
+		{&DisplayScale{Scale: 1, Offset: math.Inf(-1)}, "Offset"},
+		{&DisplayScale{Scale: 1, Round: -1}, "Round"},
+		{&DisplayScale{Scale: 1, Clamp: true, Min: 10, Max: 0}, "Min"},
+	} {
+		var e *DisplayScaleError
+		if err := tc.scale.Validate(); !errors.As(err, &e) || e.Field != tc.field {
+			t.Errorf("%+v.Validate() = %v, want a *DisplayScaleError for %v", *tc.scale, err, tc.field)
+		}
+	}
+
+	defer func() {
+		if _, ok := recover().(*DisplayScaleError); !ok {
+			t.Errorf("Skill with a zero Scale didn't panic with a *DisplayScaleError")
+		}
+	}()
+	(&DisplayScale{}).Skill(10)
+}
@@ -0,0 +1,33 @@
+package skills
+
+import (
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+)
+
+// Returns the margin by which performances must differ for a match not to be
+// a draw, given the chance drawProbability of two evenly matched sides
+// drawing. n is the number of players in the match, or with partial play the
+// sum of their squared weights.
+func DrawMarginForPlayers(drawProbability, beta, n float64) float64 {
+	// Derived from TrueSkill technical report (MSR-TR-2006-80), page 6
+	//
+	// draw probability = 2 * CDF(margin/(sqrt(n1+n2)*beta)) -1
+	//
+	// implies
+	//
+	// margin = inversecdf((draw probability + 1)/2) * sqrt(n1+n2) * beta
+	// n1 and n2 are the number of players on each team
+	return numerics.GaussInvCumulativeTo((drawProbability+1)/2, 0, 1) * math.Sqrt(n) * beta
+}
+
+// Returns the draw margin between two teams whose players' squared partial
+// play weights sum to weightSqrSum. Unless TeamSizeDrawMargin is set the
+// margin is the one for two players, whatever the team sizes, as it always
+// was.
+func (gi *GameInfo) DrawMargin(weightSqrSum float64) float64 {
+	if !gi.TeamSizeDrawMargin {
+		weightSqrSum = 1 + 1
+	}
+	return DrawMarginForPlayers(gi.DrawProbability, gi.Beta, weightSqrSum)
+}
@@ -0,0 +1,43 @@
+package skills
+
+import (
+	"math"
+)
+
+// Implemented by players who know how long it has been since their last
+// match, so players returning after a long break are treated as less
+// certain than regulars.
+type ElapsedTimer interface {
+	// The time since the player's last match, in the units of
+	// GameInfo.DynamicsPerTime.
+	ElapsedTime() float64
+}
+
+// Returns the time since player p's last match. Players that don't implement
+// ElapsedTimer, and negative times, count as no time at all.
+func ElapsedTime(p interface{}) float64 {
+	et, ok := p.(ElapsedTimer)
+	if !ok {
+		return 0
+	}
+	return math.Max(0, et.ElapsedTime())
+}
+
+// Returns the variance of player p's skill at the start of a match, given
+// their rating r from the end of their last one. Every match adds
+// DynamicsFactor squared, and time away adds DynamicsPerTime squared per unit
+// of time, but time away never takes the standard deviation past
+// InitialStddev.
+func (gi *GameInfo) DynamicVariance(p interface{}, r Rating) float64 {
+	return gi.dynamicVariance(r, ElapsedTime(p))
+}
+
+// Like DynamicVariance for a player away for time t.
+func (gi *GameInfo) dynamicVariance(r Rating, t float64) float64 {
+	v := r.Variance() + gi.DynamicsFactor*gi.DynamicsFactor
+	if t > 0 && gi.DynamicsPerTime > 0 {
+		ceiling := gi.InitialStddev * gi.InitialStddev
+		v = math.Max(v, math.Min(v+t*gi.DynamicsPerTime*gi.DynamicsPerTime, ceiling))
+	}
+	return v
+}
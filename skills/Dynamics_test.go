@@ -0,0 +1,46 @@
+package skills
+
+import (
+	"math"
+	"testing"
+)
+
+type awayPlayer struct {
+	id      int
+	elapsed float64
+}
+
+func (p awayPlayer) ElapsedTime() float64 { return p.elapsed }
+
+func TestDynamicVariance(t *testing.T) {
+	gi := *DefaultGameInfo()
+	gi.DynamicsPerTime = 0.5
+	tauSqr := gi.DynamicsFactor * gi.DynamicsFactor
+	r := NewRating(30, 2)
+
+	for _, tc := range []struct {
+		p    interface{}
+		want float64
+	}{
+		{1, 4 + tauSqr},
+		{awayPlayer{1, 0}, 4 + tauSqr},
+		{awayPlayer{1, -3}, 4 + tauSqr},
+		{awayPlayer{1, 10}, 4 + tauSqr + 10*0.25},
+		{awayPlayer{1, 1000}, gi.InitialStddev * gi.InitialStddev},
+	} {
+		if got := gi.DynamicVariance(tc.p, r); math.Abs(got-tc.want) > 1e-12 {
+			t.Errorf("DynamicVariance(%v) = %v, want %v", tc.p, got, tc.want)
+		}
+	}
+
+	// Time away never makes a player more certain
+	uncertain := NewRating(30, 10)
+	if got, want := gi.DynamicVariance(awayPlayer{1, 1000}, uncertain), 100+tauSqr; math.Abs(got-want) > 1e-12 {
+		t.Errorf("DynamicVariance = %v, want %v", got, want)
+	}
+
+	// Without DynamicsPerTime time away is ignored
+	if got, want := DefaultGameInfo().DynamicVariance(awayPlayer{1, 1000}, r), 4+tauSqr; math.Abs(got-want) > 1e-12 {
+		t.Errorf("DynamicVariance = %v, want %v", got, want)
+	}
+}
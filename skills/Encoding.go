@@ -0,0 +1,582 @@
+package skills
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The version of the encodings below. JSON documents for everything but a
+// lone Rating carry it in a "version" field and binary encodings start with
+// it, so the formats can change without misreading stored data.
+const EncodingVersion = 1
+
+// Ratings encode as {"mean":25,"stddev":8.333333333333334} in JSON, as the
+// mean and standard deviation separated by a space in text, and as the
+// version followed by the two float64s, big endian, in binary. All of them
+// round trip exactly.
+//
+// The JSON and text encodings carry no version: a rating is nothing but its
+// mean and standard deviation, and it is usually encoded inside a team or
+// player ratings document, which does carry one. Instead, decoding checks
+// that a rating has both numbers and that they make a valid rating,
+// returning a *RatingError if not, so a changed format can't be misread.
+
+type ratingJSON struct {
+	Mean   *float64 `json:"mean"`
+	Stddev *float64 `json:"stddev"`
+}
+
+func (r Rating) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ratingJSON{&r.mean, &r.stddev})
+}
+
+func (r *Rating) UnmarshalJSON(data []byte) error {
+	var rj ratingJSON
+	if err := json.Unmarshal(data, &rj); err != nil {
+		return err
+	}
+	if rj.Mean == nil || rj.Stddev == nil {
+		return fmt.Errorf("skills: rating %s is not a mean and a standard deviation", data)
+	}
+	return r.set(*rj.Mean, *rj.Stddev)
+}
+
+func (r Rating) MarshalText() ([]byte, error) {
+	b := strconv.AppendFloat(nil, r.mean, 'g', -1, 64)
+	b = append(b, ' ')
+	return strconv.AppendFloat(b, r.stddev, 'g', -1, 64), nil
+}
+
+func (r *Rating) UnmarshalText(text []byte) error {
+	fields := strings.Fields(string(text))
+	if len(fields) != 2 {
+		return fmt.Errorf("skills: rating %q is not a mean and a standard deviation", text)
+	}
+	mean, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return err
+	}
+	stddev, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return err
+	}
+	return r.set(mean, stddev)
+}
+
+func (r Rating) MarshalBinary() ([]byte, error) {
+	return appendRating([]byte{EncodingVersion}, r), nil
+}
+
+func (r *Rating) UnmarshalBinary(data []byte) error {
+	data, err := readVersion("Rating", data)
+	if err != nil {
+		return err
+	}
+	rating, data, err := readRating(data)
+	if err != nil {
+		return err
+	}
+	if len(data) != 0 {
+		return errTrailingData
+	}
+	*r = rating
+	return nil
+}
+
+// Sets r to a decoded rating if it's valid.
+func (r *Rating) set(mean, stddev float64) error {
+	decoded := NewRating(mean, stddev)
+	if !isValidRating(decoded) {
+		return &RatingError{nil, decoded}
+	}
+	*r = decoded
+	return nil
+}
+
+// Game info encodes with its field names in lower camel case, and decoding
+// validates it.
+
+type gameInfoJSON struct {
+	Version            int     `json:"version"`
+	InitialMean        float64 `json:"initialMean"`
+	DrawProbability    float64 `json:"drawProbability"`
+	InitialStddev      float64 `json:"initialStddev"`
+	Beta               float64 `json:"beta"`
+	DynamicsFactor     float64 `json:"dynamicsFactor"`
+	DynamicsPerTime    float64 `json:"dynamicsPerTime"`
+	TeamSizeDrawMargin bool    `json:"teamSizeDrawMargin"`
+}
+
+func (gi GameInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(gameInfoJSON{
+		EncodingVersion,
+		gi.InitialMean,
+		gi.DrawProbability,
+		gi.InitialStddev,
+		gi.Beta,
+		gi.DynamicsFactor,
+		gi.DynamicsPerTime,
+		gi.TeamSizeDrawMargin,
+	})
+}
+
+func (gi *GameInfo) UnmarshalJSON(data []byte) error {
+	var gj gameInfoJSON
+	if err := json.Unmarshal(data, &gj); err != nil {
+		return err
+	}
+	if gj.Version != EncodingVersion {
+		return &VersionError{"GameInfo", gj.Version}
+	}
+	decoded := GameInfo{
+		InitialMean:        gj.InitialMean,
+		DrawProbability:    gj.DrawProbability,
+		InitialStddev:      gj.InitialStddev,
+		Beta:               gj.Beta,
+		DynamicsFactor:     gj.DynamicsFactor,
+		DynamicsPerTime:    gj.DynamicsPerTime,
+		TeamSizeDrawMargin: gj.TeamSizeDrawMargin,
+	}
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+	*gi = decoded
+	return nil
+}
+
+// The text encoding of game info is its JSON encoding.
+func (gi GameInfo) MarshalText() ([]byte, error) {
+	return gi.MarshalJSON()
+}
+
+func (gi *GameInfo) UnmarshalText(text []byte) error {
+	return gi.UnmarshalJSON(text)
+}
+
+func (gi GameInfo) MarshalBinary() ([]byte, error) {
+	b := []byte{EncodingVersion}
+	for _, f := range []float64{gi.InitialMean, gi.DrawProbability, gi.InitialStddev, gi.Beta, gi.DynamicsFactor, gi.DynamicsPerTime} {
+		b = binary.BigEndian.AppendUint64(b, math.Float64bits(f))
+	}
+	if gi.TeamSizeDrawMargin {
+		return append(b, 1), nil
+	}
+	return append(b, 0), nil
+}
+
+func (gi *GameInfo) UnmarshalBinary(data []byte) error {
+	data, err := readVersion("GameInfo", data)
+	if err != nil {
+		return err
+	}
+	if len(data) != 6*8+1 {
+		return errShortData
+	}
+	var decoded GameInfo
+	for _, f := range []*float64{&decoded.InitialMean, &decoded.DrawProbability, &decoded.InitialStddev, &decoded.Beta, &decoded.DynamicsFactor, &decoded.DynamicsPerTime} {
+		*f = math.Float64frombits(binary.BigEndian.Uint64(data))
+		data = data[8:]
+	}
+	decoded.TeamSizeDrawMargin = data[0] != 0
+	if err := decoded.Validate(); err != nil {
+		return err
+	}
+	*gi = decoded
+	return nil
+}
+
+// Teams and player ratings encode as a list of players and their ratings, a
+// team's in its order and player ratings' sorted by player. Players must be
+// strings, numbers or booleans. JSON doesn't say what type a number is, so
+// players decoded from JSON that are whole numbers come back as ints;
+// TeamOf and PlayerRatingsOf decode players as their own type instead. The
+// binary encoding keeps strings, floats and booleans but also decodes every
+// integer type as int. The text encoding is the JSON encoding.
+
+type playerRatingJSON struct {
+	Player json.RawMessage `json:"player"`
+	Rating *Rating         `json:"rating"`
+}
+
+type playerRatingsJSON struct {
+	Version int                `json:"version"`
+	Players []playerRatingJSON `json:"players"`
+}
+
+func (t Team) MarshalJSON() ([]byte, error) {
+	return marshalPlayerRatings(t.Players(), t.PlayerRatings)
+}
+
+func (t *Team) UnmarshalJSON(data []byte) error {
+	decoded := NewTeam()
+	err := unmarshalPlayerRatings("Team", data, func(raw json.RawMessage, r Rating) error {
+		p, err := decodePlayer(raw)
+		if err != nil {
+			return err
+		}
+		decoded.AddPlayer(p, r)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*t = decoded
+	return nil
+}
+
+func (t Team) MarshalText() ([]byte, error) {
+	return t.MarshalJSON()
+}
+
+func (t *Team) UnmarshalText(text []byte) error {
+	return t.UnmarshalJSON(text)
+}
+
+func (t Team) MarshalBinary() ([]byte, error) {
+	return appendPlayerRatings([]byte{EncodingVersion}, t.Players(), t.PlayerRatings)
+}
+
+func (t *Team) UnmarshalBinary(data []byte) error {
+	decoded := NewTeam()
+	if err := readPlayerRatings("Team", data, decoded.AddPlayer); err != nil {
+		return err
+	}
+	*t = decoded
+	return nil
+}
+
+func (pr PlayerRatings) MarshalJSON() ([]byte, error) {
+	players, err := sortedPlayers(pr)
+	if err != nil {
+		return nil, err
+	}
+	return marshalPlayerRatings(players, pr)
+}
+
+func (pr *PlayerRatings) UnmarshalJSON(data []byte) error {
+	decoded := make(PlayerRatings)
+	err := unmarshalPlayerRatings("PlayerRatings", data, func(raw json.RawMessage, r Rating) error {
+		p, err := decodePlayer(raw)
+		if err != nil {
+			return err
+		}
+		decoded[p] = r
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*pr = decoded
+	return nil
+}
+
+func (pr PlayerRatings) MarshalText() ([]byte, error) {
+	return pr.MarshalJSON()
+}
+
+func (pr *PlayerRatings) UnmarshalText(text []byte) error {
+	return pr.UnmarshalJSON(text)
+}
+
+func (pr PlayerRatings) MarshalBinary() ([]byte, error) {
+	players, err := sortedPlayers(pr)
+	if err != nil {
+		return nil, err
+	}
+	return appendPlayerRatings([]byte{EncodingVersion}, players, pr)
+}
+
+func (pr *PlayerRatings) UnmarshalBinary(data []byte) error {
+	decoded := make(PlayerRatings)
+	err := readPlayerRatings("PlayerRatings", data, func(p interface{}, r Rating) {
+		decoded[p] = r
+	})
+	if err != nil {
+		return err
+	}
+	*pr = decoded
+	return nil
+}
+
+func (t TeamOf[K]) MarshalJSON() ([]byte, error) {
+	return marshalPlayerRatings(t.Players(), t.PlayerRatingsOf)
+}
+
+func (t *TeamOf[K]) UnmarshalJSON(data []byte) error {
+	decoded := NewTeamOf[K]()
+	err := unmarshalPlayerRatings("TeamOf", data, func(raw json.RawMessage, r Rating) error {
+		var p K
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return err
+		}
+		decoded.AddPlayer(p, r)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*t = decoded
+	return nil
+}
+
+func (pr PlayerRatingsOf[K]) MarshalJSON() ([]byte, error) {
+	players := make([]K, 0, len(pr))
+	for p := range pr {
+		players = append(players, p)
+	}
+	if err := sortPlayers(players); err != nil {
+		return nil, err
+	}
+	return marshalPlayerRatings(players, pr)
+}
+
+func (pr *PlayerRatingsOf[K]) UnmarshalJSON(data []byte) error {
+	decoded := make(PlayerRatingsOf[K])
+	err := unmarshalPlayerRatings("PlayerRatingsOf", data, func(raw json.RawMessage, r Rating) error {
+		var p K
+		if err := json.Unmarshal(raw, &p); err != nil {
+			return err
+		}
+		decoded[p] = r
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*pr = decoded
+	return nil
+}
+
+func marshalPlayerRatings[K comparable](players []K, ratings map[K]Rating) ([]byte, error) {
+	prj := playerRatingsJSON{EncodingVersion, make([]playerRatingJSON, len(players))}
+	for i, p := range players {
+		if err := checkPlayer(p); err != nil {
+			return nil, err
+		}
+		raw, err := json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+		r := ratings[p]
+		prj.Players[i] = playerRatingJSON{raw, &r}
+	}
+	return json.Marshal(prj)
+}
+
+func unmarshalPlayerRatings(typ string, data []byte, add func(raw json.RawMessage, r Rating) error) error {
+	var prj playerRatingsJSON
+	if err := json.Unmarshal(data, &prj); err != nil {
+		return err
+	}
+	if prj.Version != EncodingVersion {
+		return &VersionError{typ, prj.Version}
+	}
+	for _, e := range prj.Players {
+		if e.Rating == nil {
+			return fmt.Errorf("skills: player %s has no rating", e.Player)
+		}
+		if err := add(e.Player, *e.Rating); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the player encoded in raw, with whole numbers as ints.
+func decodePlayer(raw json.RawMessage) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	switch p := v.(type) {
+	case json.Number:
+		if i, err := strconv.Atoi(p.String()); err == nil {
+			return i, nil
+		}
+		return p.Float64()
+	case string, bool:
+		return p, nil
+	}
+	return nil, fmt.Errorf("skills: can't decode player %s: players must be strings, numbers or booleans", raw)
+}
+
+// Returns an error unless p is a string, number or boolean.
+func checkPlayer(p interface{}) error {
+	switch reflect.ValueOf(p).Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return nil
+	}
+	return fmt.Errorf("skills: can't encode player %v of type %T: players must be strings, numbers or booleans", p, p)
+}
+
+// Returns the players in pr sorted so the encoding doesn't depend on map
+// order.
+func sortedPlayers(pr PlayerRatings) ([]interface{}, error) {
+	players := make([]interface{}, 0, len(pr))
+	for p := range pr {
+		players = append(players, p)
+	}
+	return players, sortPlayers(players)
+}
+
+func sortPlayers[K comparable](players []K) error {
+	for _, p := range players {
+		if err := checkPlayer(p); err != nil {
+			return err
+		}
+	}
+	sortByPrint(players)
+	return nil
+}
+
+// The tags that start each player in the binary encoding.
+const (
+	playerString = iota + 1
+	playerInt
+	playerFloat
+	playerBool
+)
+
+func appendPlayerRatings(b []byte, players []interface{}, ratings PlayerRatings) ([]byte, error) {
+	b = binary.AppendUvarint(b, uint64(len(players)))
+	for _, p := range players {
+		var err error
+		if b, err = appendPlayer(b, p); err != nil {
+			return nil, err
+		}
+		b = appendRating(b, ratings[p])
+	}
+	return b, nil
+}
+
+func readPlayerRatings(typ string, data []byte, add func(p interface{}, r Rating)) error {
+	data, err := readVersion(typ, data)
+	if err != nil {
+		return err
+	}
+	n, size := binary.Uvarint(data)
+	if size <= 0 {
+		return errShortData
+	}
+	data = data[size:]
+	for i := uint64(0); i < n; i++ {
+		var p interface{}
+		if p, data, err = readPlayer(data); err != nil {
+			return err
+		}
+		var r Rating
+		if r, data, err = readRating(data); err != nil {
+			return err
+		}
+		add(p, r)
+	}
+	if len(data) != 0 {
+		return errTrailingData
+	}
+	return nil
+}
+
+func appendPlayer(b []byte, p interface{}) ([]byte, error) {
+	v := reflect.ValueOf(p)
+	switch v.Kind() {
+	case reflect.String:
+		b = append(b, playerString)
+		b = binary.AppendUvarint(b, uint64(v.Len()))
+		return append(b, v.String()...), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(append(b, playerInt), v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("skills: can't encode player %v: too large for an int", p)
+		}
+		return binary.AppendVarint(append(b, playerInt), int64(v.Uint())), nil
+	case reflect.Float32, reflect.Float64:
+		return binary.BigEndian.AppendUint64(append(b, playerFloat), math.Float64bits(v.Float())), nil
+	case reflect.Bool:
+		if v.Bool() {
+			return append(b, playerBool, 1), nil
+		}
+		return append(b, playerBool, 0), nil
+	}
+	return nil, checkPlayer(p)
+}
+
+func readPlayer(data []byte) (interface{}, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, errShortData
+	}
+	tag, data := data[0], data[1:]
+	switch tag {
+	case playerString:
+		n, size := binary.Uvarint(data)
+		if size <= 0 || uint64(len(data)-size) < n {
+			return nil, nil, errShortData
+		}
+		data = data[size:]
+		return string(data[:n]), data[n:], nil
+	case playerInt:
+		i, size := binary.Varint(data)
+		if size <= 0 {
+			return nil, nil, errShortData
+		}
+		if i < math.MinInt || i > math.MaxInt {
+			return nil, nil, fmt.Errorf("skills: player %v is too large for an int", i)
+		}
+		return int(i), data[size:], nil
+	case playerFloat:
+		if len(data) < 8 {
+			return nil, nil, errShortData
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(data)), data[8:], nil
+	case playerBool:
+		if len(data) < 1 {
+			return nil, nil, errShortData
+		}
+		return data[0] != 0, data[1:], nil
+	}
+	return nil, nil, fmt.Errorf("skills: unknown player tag %v", tag)
+}
+
+func appendRating(b []byte, r Rating) []byte {
+	b = binary.BigEndian.AppendUint64(b, math.Float64bits(r.mean))
+	return binary.BigEndian.AppendUint64(b, math.Float64bits(r.stddev))
+}
+
+func readRating(data []byte) (Rating, []byte, error) {
+	if len(data) < 16 {
+		return Rating{}, nil, errShortData
+	}
+	var r Rating
+	mean := math.Float64frombits(binary.BigEndian.Uint64(data))
+	stddev := math.Float64frombits(binary.BigEndian.Uint64(data[8:]))
+	if err := r.set(mean, stddev); err != nil {
+		return Rating{}, nil, err
+	}
+	return r, data[16:], nil
+}
+
+func readVersion(typ string, data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, errShortData
+	}
+	if v := int(data[0]); v != EncodingVersion {
+		return nil, &VersionError{typ, v}
+	}
+	return data[1:], nil
+}
+
+var (
+	errShortData    = fmt.Errorf("skills: binary data is too short")
+	errTrailingData = fmt.Errorf("skills: binary data has trailing bytes")
+)
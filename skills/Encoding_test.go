@@ -0,0 +1,239 @@
+package skills
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRatingRoundTrip(t *testing.T) {
+	r := NewRating(25, 25.0/3)
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"mean":25,"stddev":8.333333333333334}`; got != want {
+		t.Errorf("json = %s, want %s", got, want)
+	}
+
+	text, _ := r.MarshalText()
+	if got, want := string(text), "25 8.333333333333334"; got != want {
+		t.Errorf("text = %q, want %q", got, want)
+	}
+
+	bin, _ := r.MarshalBinary()
+	if len(bin) != 17 {
+		t.Errorf("binary is %v bytes, want 17", len(bin))
+	}
+
+	var got Rating
+	if err := json.Unmarshal(data, &got); err != nil || got != r {
+		t.Errorf("json round trip = %v, %v, want %v", got, err, r)
+	}
+	got = Rating{}
+	if err := got.UnmarshalText(text); err != nil || got != r {
+		t.Errorf("text round trip = %v, %v, want %v", got, err, r)
+	}
+	got = Rating{}
+	if err := got.UnmarshalBinary(bin); err != nil || got != r {
+		t.Errorf("binary round trip = %v, %v, want %v", got, err, r)
+	}
+}
+
+func TestRatingDecodeErrors(t *testing.T) {
+	var r Rating
+	for _, data := range []string{
+		`{}`,
+		`{"mean":25}`,
+		`{"mean":25,"stddev":0}`,
+		`{"mean":25,"stddev":-1}`,
+	} {
+		if err := json.Unmarshal([]byte(data), &r); err == nil {
+			t.Errorf("json.Unmarshal(%s) succeeded", data)
+		}
+	}
+
+	var re *RatingError
+	if err := r.UnmarshalText([]byte("25 NaN")); !errors.As(err, &re) || re.Player != nil {
+		t.Errorf("err = %v, want a *RatingError with no player", err)
+	}
+	bin, _ := NewRating(25, 1).MarshalBinary()
+	bin[len(bin)-8] ^= 0x80 // negate the standard deviation
+	if err := r.UnmarshalBinary(bin); !errors.As(err, &re) {
+		t.Errorf("err = %v, want a *RatingError", err)
+	}
+	if r != (Rating{}) {
+		t.Errorf("failed decodes changed the rating to %v", r)
+	}
+
+	var pr PlayerRatings
+	if err := json.Unmarshal([]byte(`{"version":1,"players":[{"player":"a"}]}`), &pr); err == nil {
+		t.Errorf("json.Unmarshal of a player with no rating succeeded")
+	}
+}
+
+func TestRatingAsMapKey(t *testing.T) {
+	m := map[Rating]int{NewRating(1, 2): 3}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[Rating]int
+	if err := json.Unmarshal(data, &got); err != nil || !reflect.DeepEqual(got, m) {
+		t.Errorf("round trip of %s = %v, %v, want %v", data, got, err, m)
+	}
+}
+
+func TestGameInfoRoundTrip(t *testing.T) {
+	gi := NewGameInfo(WithDynamicsPerTime(0.5), WithTeamSizeDrawMargin(true))
+
+	data, err := json.Marshal(gi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromJSON GameInfo
+	if err := json.Unmarshal(data, &fromJSON); err != nil || fromJSON != *gi {
+		t.Errorf("json round trip = %+v, %v, want %+v", fromJSON, err, *gi)
+	}
+
+	data, _ = gi.MarshalBinary()
+	var fromBinary GameInfo
+	if err := fromBinary.UnmarshalBinary(data); err != nil || fromBinary != *gi {
+		t.Errorf("binary round trip = %+v, %v, want %+v", fromBinary, err, *gi)
+	}
+}
+
+func TestGameInfoDecodeErrors(t *testing.T) {
+	var gi GameInfo
+
+	err := json.Unmarshal([]byte(`{"version":2,"beta":1}`), &gi)
+	var ve *VersionError
+	if !errors.As(err, &ve) || ve.Version != 2 {
+		t.Errorf("err = %v, want a *VersionError for version 2", err)
+	}
+
+	err = json.Unmarshal([]byte(`{"version":1,"initialStddev":1,"beta":-1}`), &gi)
+	var ge *GameInfoError
+	if !errors.As(err, &ge) || ge.Field != "Beta" {
+		t.Errorf("err = %v, want a *GameInfoError for Beta", err)
+	}
+
+	data, _ := DefaultGameInfo().MarshalBinary()
+	if err := gi.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Errorf("UnmarshalBinary of short data succeeded")
+	}
+}
+
+func TestTeamRoundTrip(t *testing.T) {
+	team := NewTeam()
+	team.AddPlayer("b", NewRating(20, 5))
+	team.AddPlayer(7, NewRating(25, 3))
+	team.AddPlayer(2.5, NewRating(30, 4))
+	team.AddPlayer(true, NewRating(35, 6))
+
+	data, err := json.Marshal(team)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromJSON Team
+	if err := json.Unmarshal(data, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	assertSameTeam(t, "json", fromJSON, team)
+
+	data, err = team.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromBinary Team
+	if err := fromBinary.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	assertSameTeam(t, "binary", fromBinary, team)
+}
+
+func assertSameTeam(t *testing.T, name string, got, want Team) {
+	t.Helper()
+	if !reflect.DeepEqual(got.Players(), want.Players()) || !reflect.DeepEqual(got.PlayerRatings, want.PlayerRatings) {
+		t.Errorf("%v round trip = %v %v, want %v %v", name, got.Players(), got.PlayerRatings, want.Players(), want.PlayerRatings)
+	}
+}
+
+func TestPlayerRatingsRoundTrip(t *testing.T) {
+	pr := PlayerRatings{
+		"alice": NewRating(20, 5),
+		"bob":   NewRating(25, 3),
+		3:       NewRating(30, 4),
+	}
+
+	data, err := json.Marshal(pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := json.Marshal(pr)
+	if string(again) != string(data) {
+		t.Errorf("json encodings differ: %s and %s", data, again)
+	}
+	var fromJSON PlayerRatings
+	if err := json.Unmarshal(data, &fromJSON); err != nil || !reflect.DeepEqual(fromJSON, pr) {
+		t.Errorf("json round trip = %v, %v, want %v", fromJSON, err, pr)
+	}
+
+	data, err = pr.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromBinary PlayerRatings
+	if err := fromBinary.UnmarshalBinary(data); err != nil || !reflect.DeepEqual(fromBinary, pr) {
+		t.Errorf("binary round trip = %v, %v, want %v", fromBinary, err, pr)
+	}
+}
+
+func TestPlayerRatingsUnsupportedPlayer(t *testing.T) {
+	pr := PlayerRatings{&struct{ id int }{1}: NewRating(20, 5)}
+
+	if _, err := json.Marshal(pr); err == nil {
+		t.Errorf("json.Marshal succeeded")
+	}
+	if _, err := pr.MarshalBinary(); err == nil {
+		t.Errorf("MarshalBinary succeeded")
+	}
+
+	var got PlayerRatings
+	err := json.Unmarshal([]byte(`{"version":1,"players":[{"player":{"id":1},"rating":{"mean":1,"stddev":1}}]}`), &got)
+	if err == nil {
+		t.Errorf("json.Unmarshal succeeded")
+	}
+}
+
+func TestTypedRoundTrip(t *testing.T) {
+	type id int64
+
+	team := NewTeamOf[id]()
+	team.AddPlayer(9, NewRating(20, 5))
+	team.AddPlayer(4, NewRating(25, 3))
+
+	data, err := json.Marshal(team)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got TeamOf[id]
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Players(), team.Players()) || !reflect.DeepEqual(got.PlayerRatingsOf, team.PlayerRatingsOf) {
+		t.Errorf("round trip = %v, want %v", got.PlayerRatingsOf, team.PlayerRatingsOf)
+	}
+
+	data, err = json.Marshal(team.PlayerRatingsOf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pr PlayerRatingsOf[id]
+	if err := json.Unmarshal(data, &pr); err != nil || !reflect.DeepEqual(pr, team.PlayerRatingsOf) {
+		t.Errorf("round trip = %v, %v, want %v", pr, err, team.PlayerRatingsOf)
+	}
+}
@@ -0,0 +1,134 @@
+package skills
+
+import (
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+)
+
+// Returned when a calculator is given a number of teams it doesn't support.
+type TeamCountError struct {
+	Count   int
+	Allowed numerics.Range
+}
+
+func (e *TeamCountError) Error() string {
+	return fmt.Sprintf("len(teams) [%v] outside of expected range [%v]", e.Count, e.Allowed)
+}
+
+// Returned when a team has a number of players the calculator doesn't
+// support, including empty teams.
+type PlayerCountError struct {
+	Team    int // index of the team in the calculator's input
+	Count   int
+	Allowed numerics.Range
+}
+
+func (e *PlayerCountError) Error() string {
+	return fmt.Sprintf("teams[%v].PlayerCount [%v] outside of expected range [%v]", e.Team, e.Count, e.Allowed)
+}
+
+// Returned when the number of ranks does not match the number of teams.
+type RankCountError struct {
+	Teams int
+	Ranks int
+}
+
+func (e *RankCountError) Error() string {
+	return fmt.Sprintf("Number of teams [%v] does not match number of ranks [%v]", e.Teams, e.Ranks)
+}
+
+// Returned when a player's rating has a mean that isn't finite or a standard
+// deviation that isn't finite and positive, or with no Player when such a
+// rating is decoded.
+type RatingError struct {
+	Player interface{}
+	Rating Rating
+}
+
+func (e *RatingError) Error() string {
+	if e.Player == nil {
+		return fmt.Sprintf("invalid rating [%v]", e.Rating)
+	}
+	return fmt.Sprintf("player [%v] has invalid rating [%v]", e.Player, e.Rating)
+}
+
+// Returned when a PartialPlayer reports a partial play percentage outside
+// [0, 1], NaN included.
+type PartialPlayError struct {
+	Player     interface{}
+	Percentage float64
+}
+
+func (e *PartialPlayError) Error() string {
+	return fmt.Sprintf("player [%v] partial play percentage [%v] outside of expected range [0, 1]", e.Player, e.Percentage)
+}
+
+// Returned when a GameInfo field holds a value the calculators can't use, or
+// with no Field when there is no GameInfo at all.
+type GameInfoError struct {
+	Field string
+	Value float64
+}
+
+func (e *GameInfoError) Error() string {
+	if e.Field == "" {
+		return "GameInfo is nil"
+	}
+	return fmt.Sprintf("GameInfo.%v [%v] is invalid", e.Field, e.Value)
+}
+
+// Returned when a DisplayScale field holds a value that can't map ratings to
+// display values and back.
+type DisplayScaleError struct {
+	Field string
+	Value float64
+}
+
+func (e *DisplayScaleError) Error() string {
+	return fmt.Sprintf("DisplayScale.%v [%v] is invalid", e.Field, e.Value)
+}
+
+// Returned when a trueskill.ScoreMarginCalc field holds a value it can't use.
+type ScoreMarginError struct {
+	Field string
+	Value float64
+}
+
+func (e *ScoreMarginError) Error() string {
+	return fmt.Sprintf("ScoreMarginCalc.%v [%v] is invalid", e.Field, e.Value)
+}
+
+// Returned when a team's score isn't finite.
+type ScoreError struct {
+	Team  int // index of the team in the calculator's input
+	Score float64
+}
+
+func (e *ScoreError) Error() string {
+	return fmt.Sprintf("scores[%v] [%v] is invalid", e.Team, e.Score)
+}
+
+// Returned when decoding data written with a version of the encoding this
+// package doesn't know.
+type VersionError struct {
+	Type    string
+	Version int
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("%v encoding version [%v] is not supported", e.Type, e.Version)
+}
+
+// Returned when a match in a history can't be rated.
+type MatchError struct {
+	Match int // index of the match in the history
+	Err   error
+}
+
+func (e *MatchError) Error() string {
+	return fmt.Sprintf("matches[%v]: %v", e.Match, e.Err)
+}
+
+func (e *MatchError) Unwrap() error {
+	return e.Err
+}
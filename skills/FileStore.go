@@ -0,0 +1,153 @@
+package skills
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// A FileStore is a Store that keeps its ratings in memory and appends every
+// change to a file, from which OpenFileStore restores them. Each Put or
+// CompareAndSwap appends one line holding the ratings it set, encoded as for
+// PlayerRatings, so a change is never half applied: a line cut short by a
+// crash is dropped when the file is next opened.
+//
+// Players are encoded as for PlayerRatings too. Only players of type string,
+// int or bool come back from the file as the same key they were stored
+// under, so those are the only ones a FileStore accepts; others, named
+// string types and int64 included, are rejected rather than lost on reopening.
+type FileStore struct {
+	mu      sync.Mutex
+	f       *os.File
+	size    int64 // the length of the file's complete lines
+	ratings PlayerRatings
+}
+
+// OpenFileStore opens the store in the named file, creating the file if it
+// doesn't exist.
+func OpenFileStore(name string) (*FileStore, error) {
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		return nil, err
+	}
+	s := &FileStore{f: f, ratings: make(PlayerRatings)}
+	if err := s.load(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileStore) load() error {
+	data, err := io.ReadAll(s.f)
+	if err != nil {
+		return err
+	}
+
+	for line := 1; ; line++ {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		err := unmarshalPlayerRatings("FileStore", data[:i], func(raw json.RawMessage, r Rating) error {
+			p, err := decodePlayer(raw)
+			if err != nil {
+				return err
+			}
+			s.ratings[p] = r
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("skills: %v line %v: %w", s.f.Name(), line, err)
+		}
+		data = data[i+1:]
+		s.size += int64(i + 1)
+	}
+
+	// Drop an unfinished last line so the next one starts afresh
+	if len(data) > 0 {
+		return s.f.Truncate(s.size)
+	}
+	return nil
+}
+
+// Close closes the file. The store can't be changed afterwards.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
+
+func (s *FileStore) Get(p interface{}) (Rating, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.ratings[p]
+	return r, ok, nil
+}
+
+func (s *FileStore) GetMany(players ...interface{}) (PlayerRatings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return getMany(s.ratings, players), nil
+}
+
+func (s *FileStore) Put(p interface{}, r Rating) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.write(PlayerRatings{p: r})
+}
+
+func (s *FileStore) CompareAndSwap(old, new PlayerRatings) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !unchanged(s.ratings, old, new) {
+		return false, nil
+	}
+	if err := s.write(new); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Appends ratings to the file and then sets them in memory.
+func (s *FileStore) write(ratings PlayerRatings) error {
+	for p := range ratings {
+		if err := checkFileStorePlayer(p); err != nil {
+			return err
+		}
+	}
+
+	line, err := ratings.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	_, err = s.f.Write(line)
+	if err == nil {
+		err = s.f.Sync()
+	}
+	if err != nil {
+		// Don't leave part of a line for the next one to be appended to
+		s.f.Truncate(s.size)
+		return err
+	}
+	s.size += int64(len(line))
+
+	for p, r := range ratings {
+		s.ratings[p] = r
+	}
+	return nil
+}
+
+// Returns an error unless p decodes from the file as the same key.
+func checkFileStorePlayer(p interface{}) error {
+	switch p.(type) {
+	case string, int, bool:
+		return nil
+	}
+	return fmt.Errorf("skills: can't store player %v of type %T: a FileStore only keeps string, int and bool players", p, p)
+}
@@ -14,16 +14,129 @@ type GameInfo struct {
 	InitialStddev   float64
 	Beta            float64
 	DynamicsFactor  float64
+
+	// How much skill drifts per unit of time a player is away, as a
+	// standard deviation; see DynamicVariance. Zero ignores time away.
+	DynamicsPerTime float64
+
+	// Whether TrueSkill derives the draw margin from the number of players
+	// in the match, as the TrueSkill paper does. The original
+	// implementation always used the margin for two players; that remains
+	// the default so existing ratings can be reproduced.
+	TeamSizeDrawMargin bool
 }
 
 func (this *GameInfo) DefaultRating() Rating {
 	return NewRating(this.InitialMean, this.InitialStddev)
 }
 
-var DefaultGameInfo = &GameInfo{
-	InitialMean:     defaultInitialMean,
-	DrawProbability: defaultDrawProbability,
-	InitialStddev:   defaultInitialStddev,
-	Beta:            defaultBeta,
-	DynamicsFactor:  defaultDynamicsFactor,
+// Returns a new copy of the game info the TrueSkill paper uses, so changes
+// to it don't leak into other calculations.
+func DefaultGameInfo() *GameInfo {
+	return &GameInfo{
+		InitialMean:     defaultInitialMean,
+		DrawProbability: defaultDrawProbability,
+		InitialStddev:   defaultInitialStddev,
+		Beta:            defaultBeta,
+		DynamicsFactor:  defaultDynamicsFactor,
+	}
+}
+
+// Changes one or more GameInfo fields; see NewGameInfo.
+type GameInfoOption func(gi *GameInfo)
+
+// Returns DefaultGameInfo with opts applied in order. It panics if the
+// result is invalid.
+func NewGameInfo(opts ...GameInfoOption) *GameInfo {
+	gi, err := TryNewGameInfo(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return gi
+}
+
+// Like NewGameInfo but returns a *GameInfoError instead of panicking if the
+// result is invalid.
+func TryNewGameInfo(opts ...GameInfoOption) (*GameInfo, error) {
+	gi := DefaultGameInfo()
+	for _, opt := range opts {
+		opt(gi)
+	}
+	if err := gi.Validate(); err != nil {
+		return nil, err
+	}
+	return gi, nil
+}
+
+// Sets the initial mean and scales the initial standard deviation, Beta and
+// DynamicsFactor in the default proportions, which is all it takes to move
+// ratings to another range. Apply it before options for those fields.
+func WithScale(initialMean float64) GameInfoOption {
+	return func(gi *GameInfo) {
+		gi.InitialMean = initialMean
+		gi.InitialStddev = initialMean / 3
+		gi.Beta = initialMean / 6
+		gi.DynamicsFactor = initialMean / 300
+	}
+}
+
+func WithInitialMean(v float64) GameInfoOption {
+	return func(gi *GameInfo) { gi.InitialMean = v }
+}
+
+func WithInitialStddev(v float64) GameInfoOption {
+	return func(gi *GameInfo) { gi.InitialStddev = v }
+}
+
+func WithBeta(v float64) GameInfoOption {
+	return func(gi *GameInfo) { gi.Beta = v }
+}
+
+func WithDynamicsFactor(v float64) GameInfoOption {
+	return func(gi *GameInfo) { gi.DynamicsFactor = v }
+}
+
+func WithDrawProbability(v float64) GameInfoOption {
+	return func(gi *GameInfo) { gi.DrawProbability = v }
+}
+
+func WithDynamicsPerTime(v float64) GameInfoOption {
+	return func(gi *GameInfo) { gi.DynamicsPerTime = v }
+}
+
+func WithTeamSizeDrawMargin(on bool) GameInfoOption {
+	return func(gi *GameInfo) { gi.TeamSizeDrawMargin = on }
+}
+
+// Returns game info for one on one games with frequent draws, such as chess.
+// About a third of games between evenly matched players are drawn, and a
+// single result says less than usual about who is better, so Beta is larger
+// than the default.
+func ChessGameInfo() *GameInfo {
+	return NewGameInfo(
+		WithDrawProbability(0.33),
+		WithBeta(defaultBeta*1.5),
+	)
+}
+
+// Returns game info for team games that rarely end level, such as team
+// shooters. The draw margin grows with the number of players, so draws stay
+// as rare in 8v8 as in 1v1, and the larger DynamicsFactor lets ratings keep
+// up with players who improve quickly.
+func TeamShooterGameInfo() *GameInfo {
+	return NewGameInfo(
+		WithDrawProbability(0.02),
+		WithTeamSizeDrawMargin(true),
+		WithDynamicsFactor(defaultDynamicsFactor*2),
+	)
+}
+
+// Returns game info for free-for-all games with many players and no shared
+// places. Performances in a crowded lobby vary more from match to match, so
+// Beta is larger than the default, and draws are all but ruled out.
+func FreeForAllGameInfo() *GameInfo {
+	return NewGameInfo(
+		WithDrawProbability(0.001),
+		WithBeta(defaultBeta*1.5),
+	)
 }
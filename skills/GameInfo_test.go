@@ -0,0 +1,71 @@
+package skills
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDefaultGameInfoIsACopy(t *testing.T) {
+	gi := DefaultGameInfo()
+	gi.Beta = 100
+
+	if got := DefaultGameInfo().Beta; got != defaultBeta {
+		t.Errorf("Beta = %v after changing a copy, want %v", got, defaultBeta)
+	}
+}
+
+func TestNewGameInfo(t *testing.T) {
+	if got, want := *NewGameInfo(), *DefaultGameInfo(); got != want {
+		t.Errorf("NewGameInfo() = %+v, want %+v", got, want)
+	}
+
+	gi := NewGameInfo(WithScale(1500), WithDrawProbability(0), WithTeamSizeDrawMargin(true))
+	want := GameInfo{
+		InitialMean:        1500,
+		InitialStddev:      500,
+		Beta:               250,
+		DynamicsFactor:     5,
+		TeamSizeDrawMargin: true,
+	}
+	if *gi != want {
+		t.Errorf("NewGameInfo(...) = %+v, want %+v", *gi, want)
+	}
+}
+
+func TestNewGameInfoInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		field string
+		opt   GameInfoOption
+	}{
+		{"InitialStddev", WithInitialStddev(0)},
+		{"Beta", WithBeta(-1)},
+		{"DynamicsFactor", WithDynamicsFactor(-1)},
+		{"DynamicsPerTime", WithDynamicsPerTime(-1)},
+		{"DrawProbability", WithDrawProbability(1)},
+	} {
+		_, err := TryNewGameInfo(tc.opt)
+		var e *GameInfoError
+		if !errors.As(err, &e) || e.Field != tc.field {
+			t.Errorf("TryNewGameInfo err = %v, want a *GameInfoError for %v", err, tc.field)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewGameInfo didn't panic on an invalid option")
+		}
+	}()
+	NewGameInfo(WithBeta(0))
+}
+
+func TestGameInfoPresets(t *testing.T) {
+	for name, gi := range map[string]*GameInfo{
+		"Chess":       ChessGameInfo(),
+		"TeamShooter": TeamShooterGameInfo(),
+		"FreeForAll":  FreeForAllGameInfo(),
+	} {
+		if err := gi.Validate(); err != nil {
+			t.Errorf("%vGameInfo().Validate() = %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package skills
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// A LedgerEntry is one match recorded in a Ledger.
+type LedgerEntry struct {
+	Time  time.Time
+	Teams [][]interface{} // the players on each team
+	Ranks []int           // as for Calc
+
+	// Identifies the game info the match was rated with when it was
+	// played, so ratings can be traced to the parameters that produced
+	// them.
+	GameInfoVersion string
+}
+
+// A Ledger records matches in time order so every rating can be recomputed
+// from scratch with Replay, for instance after changing the game info. It is
+// safe for concurrent use.
+type Ledger struct {
+	mu      sync.Mutex
+	entries []LedgerEntry
+}
+
+// Record adds e to the ledger after any matches with the same or an earlier
+// time. The ledger keeps its own copy of e's teams and ranks.
+func (l *Ledger) Record(e LedgerEntry) error {
+	if len(e.Teams) != len(e.Ranks) {
+		return &RankCountError{len(e.Teams), len(e.Ranks)}
+	}
+
+	teams := make([][]interface{}, len(e.Teams))
+	for i, t := range e.Teams {
+		teams[i] = append([]interface{}{}, t...)
+	}
+	e.Teams = teams
+	e.Ranks = append([]int{}, e.Ranks...)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	i := sort.Search(len(l.entries), func(i int) bool {
+		return l.entries[i].Time.After(e.Time)
+	})
+	l.entries = append(l.entries, LedgerEntry{})
+	copy(l.entries[i+1:], l.entries[i:])
+	l.entries[i] = e
+	return nil
+}
+
+// Entries returns the recorded matches in time order.
+func (l *Ledger) Entries() []LedgerEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]LedgerEntry{}, l.entries...)
+}
+
+// How one match changed a player's rating.
+type RatingDelta struct {
+	Before, After Rating
+}
+
+func (d RatingDelta) Mean() float64 {
+	return d.After.Mean() - d.Before.Mean()
+}
+
+func (d RatingDelta) Stddev() float64 {
+	return d.After.Stddev() - d.Before.Stddev()
+}
+
+// The outcome of replaying a ledger.
+type ReplayResult struct {
+	// Each player's rating after their last match.
+	Ratings PlayerRatings
+
+	// How each match changed its players' ratings; Deltas[i] is for the
+	// ledger's i-th entry.
+	Deltas []map[interface{}]RatingDelta
+}
+
+// Replay rates every match in l in time order with calc and gi, starting
+// every player at gi's default rating. The game info each match was recorded
+// with is ignored; gi rates them all. A match that can't be rated stops the
+// replay with a *MatchError.
+//
+// A player's elapsed time, for GameInfo.DynamicsPerTime, is the time between
+// the entries of their last match and this one, counted in units of unit.
+// Replay widens the rating calc is given by that time away, so players in a
+// ledger shouldn't implement ElapsedTimer themselves. unit must be positive
+// when gi.DynamicsPerTime is and is otherwise unused.
+func Replay(l *Ledger, calc TryCalc, gi *GameInfo, unit time.Duration) (*ReplayResult, error) {
+	if err := gi.Validate(); err != nil {
+		return nil, err
+	}
+	if gi.DynamicsPerTime > 0 && unit <= 0 {
+		return nil, fmt.Errorf("skills: Replay with a GameInfo.DynamicsPerTime needs a positive time unit, not %v", unit)
+	}
+
+	entries := l.Entries()
+	result := &ReplayResult{
+		Ratings: make(PlayerRatings),
+		Deltas:  make([]map[interface{}]RatingDelta, len(entries)),
+	}
+	lastPlayed := make(map[interface{}]time.Time)
+
+	for m, e := range entries {
+		// priors holds each player's rating after their last match and
+		// teams the same rating widened by their time away since
+		priors := make([]Team, len(e.Teams))
+		teams := make([]Team, len(e.Teams))
+		for i, t := range e.Teams {
+			priors[i], teams[i] = NewTeam(), NewTeam()
+			for _, p := range t {
+				r, ok := result.Ratings[p]
+				if !ok {
+					r = gi.DefaultRating()
+				}
+				priors[i].AddPlayer(p, r)
+				if last, ok := lastPlayed[p]; ok && gi.DynamicsPerTime > 0 && e.Time.After(last) {
+					// calc adds the DynamicsFactor itself
+					elapsed := float64(e.Time.Sub(last)) / float64(unit)
+					v := gi.dynamicVariance(r, elapsed) - gi.DynamicsFactor*gi.DynamicsFactor
+					r = NewRating(r.Mean(), math.Sqrt(v))
+				}
+				teams[i].AddPlayer(p, r)
+			}
+		}
+
+		newRatings, err := calc.TryCalcNewRatings(gi, teams, e.Ranks...)
+		if err != nil {
+			return nil, &MatchError{m, err}
+		}
+		RebasePartialUpdates(teams, priors, newRatings)
+
+		deltas := make(map[interface{}]RatingDelta, len(newRatings))
+		for _, t := range priors {
+			for _, p := range t.Players() {
+				if after, ok := newRatings[p]; ok {
+					deltas[p] = RatingDelta{t.PlayerRating(p), after}
+					result.Ratings[p] = after
+					lastPlayed[p] = e.Time
+				}
+			}
+		}
+		result.Deltas[m] = deltas
+	}
+
+	return result, nil
+}
@@ -0,0 +1,200 @@
+package skills
+
+import (
+	"errors"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Like countingCalc but refuses to rate matches with an empty team.
+type emptyTeamCalc struct {
+	countingCalc
+}
+
+func (c emptyTeamCalc) TryCalcNewRatings(gi *GameInfo, teams []Team, ranks ...int) (PlayerRatings, error) {
+	for i, t := range teams {
+		if t.PlayerCount() == 0 {
+			return nil, &PlayerCountError{i, 0, numerics.AtLeast(1)}
+		}
+	}
+	return c.countingCalc.TryCalcNewRatings(gi, teams, ranks...)
+}
+
+func TestLedgerRecordsInTimeOrder(t *testing.T) {
+	var l Ledger
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, e := range []LedgerEntry{
+		{Time: start.Add(2 * time.Hour), Teams: [][]interface{}{{"c"}, {"d"}}, Ranks: []int{1, 2}},
+		{Time: start, Teams: [][]interface{}{{"a"}, {"b"}}, Ranks: []int{1, 2}},
+		{Time: start.Add(2 * time.Hour), Teams: [][]interface{}{{"e"}, {"f"}}, Ranks: []int{1, 2}},
+	} {
+		if err := l.Record(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []interface{}
+	for _, e := range l.Entries() {
+		got = append(got, e.Teams[0][0])
+	}
+	if want := []interface{}{"a", "c", "e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("first players = %v, want %v", got, want)
+	}
+
+	var rce *RankCountError
+	if err := l.Record(LedgerEntry{Teams: [][]interface{}{{"a"}, {"b"}}, Ranks: []int{1}}); !errors.As(err, &rce) {
+		t.Errorf("err = %v, want a *RankCountError", err)
+	}
+}
+
+func TestLedgerCopiesEntries(t *testing.T) {
+	var l Ledger
+	team := []interface{}{"a"}
+	ranks := []int{1, 2}
+	l.Record(LedgerEntry{Teams: [][]interface{}{team, {"b"}}, Ranks: ranks})
+	team[0], ranks[0] = "z", 3
+
+	e := l.Entries()[0]
+	if e.Teams[0][0] != "a" || e.Ranks[0] != 1 {
+		t.Errorf("entry changed with its caller's slices: %v %v", e.Teams, e.Ranks)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	var l Ledger
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.Record(LedgerEntry{Time: start.Add(time.Hour), Teams: [][]interface{}{{"a"}, {"c"}}, Ranks: []int{2, 1}, GameInfoVersion: "v2"})
+	l.Record(LedgerEntry{Time: start, Teams: [][]interface{}{{"a"}, {"b"}}, Ranks: []int{1, 2}, GameInfoVersion: "v1"})
+
+	gi := NewGameInfo(WithScale(100))
+	result, err := Replay(&l, countingCalc{}, gi, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initial := gi.DefaultRating()
+	at := func(mean float64) Rating { return NewRating(mean, initial.Stddev()) }
+	wantRatings := PlayerRatings{"a": at(100), "b": at(99), "c": at(101)}
+	if !reflect.DeepEqual(result.Ratings, wantRatings) {
+		t.Errorf("Ratings = %v, want %v", result.Ratings, wantRatings)
+	}
+
+	wantDeltas := []map[interface{}]RatingDelta{
+		{"a": {initial, at(101)}, "b": {initial, at(99)}},
+		{"a": {at(101), at(100)}, "c": {initial, at(101)}},
+	}
+	if !reflect.DeepEqual(result.Deltas, wantDeltas) {
+		t.Errorf("Deltas = %v, want %v", result.Deltas, wantDeltas)
+	}
+	if d := result.Deltas[1]["a"]; d.Mean() != -1 || d.Stddev() != 0 {
+		t.Errorf("a's second delta = %v, %v, want -1, 0", d.Mean(), d.Stddev())
+	}
+}
+
+func TestReplayMatchError(t *testing.T) {
+	var l Ledger
+	l.Record(LedgerEntry{Teams: [][]interface{}{{"a"}, {"b"}}, Ranks: []int{1, 2}})
+	l.Record(LedgerEntry{Teams: [][]interface{}{{"a"}, {}}, Ranks: []int{1, 2}})
+
+	_, err := Replay(&l, emptyTeamCalc{}, DefaultGameInfo(), 0)
+	var me *MatchError
+	var pce *PlayerCountError
+	if !errors.As(err, &me) || me.Match != 1 || !errors.As(err, &pce) {
+		t.Errorf("err = %v, want a *MatchError for matches[1] wrapping a *PlayerCountError", err)
+	}
+}
+
+// Rates every player N(mean, 1) after a match, applying partial updates, and
+// records the variance each player had going into each match.
+type certainCalc struct {
+	countingCalc
+	variances *[]map[interface{}]float64
+}
+
+func (c certainCalc) TryCalcNewRatings(gi *GameInfo, teams []Team, ranks ...int) (PlayerRatings, error) {
+	variances := make(map[interface{}]float64)
+	for _, t := range teams {
+		for _, p := range t.Players() {
+			variances[p] = t.PlayerRating(p).Variance()
+		}
+	}
+	*c.variances = append(*c.variances, variances)
+
+	newSkills, err := c.countingCalc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		return nil, err
+	}
+	for p, r := range newSkills {
+		newSkills[p] = NewRating(r.Mean(), 1)
+	}
+	ApplyPartialUpdates(teams, newSkills)
+	return newSkills, nil
+}
+
+func TestReplayElapsedTime(t *testing.T) {
+	var l Ledger
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.Record(LedgerEntry{Time: start, Teams: [][]interface{}{{"a"}, {"b"}}, Ranks: []int{1, 2}})
+	l.Record(LedgerEntry{Time: start.Add(time.Hour), Teams: [][]interface{}{{"b"}, {"c"}}, Ranks: []int{1, 2}})
+	l.Record(LedgerEntry{Time: start.Add(3 * time.Hour), Teams: [][]interface{}{{"a"}, {"b"}}, Ranks: []int{1, 2}})
+
+	gi := NewGameInfo(WithDynamicsPerTime(1))
+	gi.DynamicsFactor = 0
+	var variances []map[interface{}]float64
+	if _, err := Replay(&l, certainCalc{variances: &variances}, gi, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each player leaves a match with a variance of 1 and gains 1 per hour
+	// away
+	initial := gi.DefaultRating().Variance()
+	want := []map[interface{}]float64{
+		{"a": initial, "b": initial},
+		{"b": 2, "c": initial},
+		{"a": 4, "b": 3},
+	}
+	for m := range want {
+		for p, v := range want[m] {
+			if math.Abs(variances[m][p]-v) > 1e-9 {
+				t.Errorf("matches[%v] variance of %v = %v, want %v", m, p, variances[m][p], v)
+			}
+		}
+	}
+
+	if _, err := Replay(&l, certainCalc{variances: &variances}, gi, 0); err == nil {
+		t.Errorf("Replay with DynamicsPerTime and no time unit succeeded")
+	}
+}
+
+func TestReplayElapsedTimePartialUpdate(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fixed := NewPartialUpdatePlayer("a", 0)
+	half := NewPartialUpdatePlayer("b", 0.5)
+	var l Ledger
+	l.Record(LedgerEntry{Time: start, Teams: [][]interface{}{{fixed}, {half}}, Ranks: []int{1, 2}})
+	l.Record(LedgerEntry{Time: start.Add(10 * time.Hour), Teams: [][]interface{}{{fixed}, {half}}, Ranks: []int{1, 2}})
+
+	var results []*ReplayResult
+	for _, perTime := range []float64{0, 0.1} {
+		var variances []map[interface{}]float64
+		result, err := Replay(&l, certainCalc{variances: &variances}, NewGameInfo(WithDynamicsPerTime(perTime)), time.Hour)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := variances[0][fixed]; !ok {
+			t.Errorf("calc wasn't given the ledger's own players: %v", variances[0])
+		}
+		results = append(results, result)
+	}
+
+	gi := DefaultGameInfo()
+	if got := results[1].Ratings[fixed]; got != gi.DefaultRating() {
+		t.Errorf("rating of a player who takes no updates = %v, want %v", got, gi.DefaultRating())
+	}
+	if got, want := results[1].Ratings[half], results[0].Ratings[half]; math.Abs(got.Mean()-want.Mean()) > 1e-9 {
+		t.Errorf("half update mean with time dynamics = %v, want %v", got.Mean(), want.Mean())
+	}
+}
@@ -0,0 +1,30 @@
+package skills
+
+// Implemented by players who may have only played part of a match.
+type PartialPlayer interface {
+	// The fraction of the match the player played, where 0 means the player
+	// didn't play and 1 means the player played the whole time.
+	PartialPlayPercentage() float64
+}
+
+// The smallest partial play percentage a calculator will use. Weights at or
+// near zero break the factor graph's weighted sum factors.
+const smallestPartialPlayPercentage = 0.0001
+
+// Returns the weight a calculator should give player p. Players that don't
+// implement PartialPlayer are assumed to have played the whole match.
+func PartialPlayPercentage(p interface{}) float64 {
+	pp, ok := p.(PartialPlayer)
+	if !ok {
+		return 1
+	}
+
+	pct := pp.PartialPlayPercentage()
+
+	// HACK to get around bug near 0
+	if pct < smallestPartialPlayPercentage {
+		pct = smallestPartialPlayPercentage
+	}
+
+	return pct
+}
@@ -0,0 +1,79 @@
+package skills
+
+import (
+	"math"
+)
+
+// Implemented by players who should only receive part of a rating update,
+// such as a substitute or a player in a placement match.
+type PartialUpdater interface {
+	// The fraction of the update the player should receive, where 0 means
+	// no update and 1 means the whole update.
+	PartialUpdatePercentage() float64
+}
+
+// Returns the fraction of the update player p should receive. Players that
+// don't implement PartialUpdater receive the whole update.
+func PartialUpdatePercentage(p interface{}) float64 {
+	pu, ok := p.(PartialUpdater)
+	if !ok {
+		return 1
+	}
+	return pu.PartialUpdatePercentage()
+}
+
+// Returns the rating that results from applying only pct of the update from
+// prior to posterior. The interpolation is done on the precision and
+// precision mean, the natural parameters TrueSkill updates in, so the
+// variance and mean move together.
+func PartialUpdateRating(prior, posterior Rating, pct float64) Rating {
+	priorPrecision := 1 / prior.Variance()
+	priorPrecisionMean := prior.Mean() * priorPrecision
+
+	postPrecision := 1 / posterior.Variance()
+	postPrecisionMean := posterior.Mean() * postPrecision
+
+	precision := priorPrecision + pct*(postPrecision-priorPrecision)
+	precisionMean := priorPrecisionMean + pct*(postPrecisionMean-priorPrecisionMean)
+
+	return NewRating(precisionMean/precision, math.Sqrt(1/precision))
+}
+
+// Damps each player's rating in posteriors toward their prior rating in
+// priors according to their partial update percentage. Calculators call this
+// on their results before returning them.
+func ApplyPartialUpdates(priors []Team, posteriors PlayerRatings) {
+	for _, t := range priors {
+		for _, p := range t.Players() {
+			prior := t.PlayerRating(p)
+			post, ok := posteriors[p]
+			if !ok {
+				continue
+			}
+			if pct := PartialUpdatePercentage(p); pct != 1 {
+				posteriors[p] = PartialUpdateRating(prior, post, pct)
+			}
+		}
+	}
+}
+
+// Moves the partial updates a calculator applied to posteriors so they start
+// from each player's rating in priors rather than in given, the teams the
+// calculator was given. given and priors must hold the same players in the
+// same order. The damping is a linear interpolation, so it can be undone
+// and then applied again from the other rating.
+func RebasePartialUpdates(given, priors []Team, posteriors PlayerRatings) {
+	for i, t := range given {
+		for _, p := range t.Players() {
+			post, ok := posteriors[p]
+			pct := PartialUpdatePercentage(p)
+			if !ok || pct == 1 {
+				continue
+			}
+			if pct != 0 {
+				post = PartialUpdateRating(t.PlayerRating(p), post, 1/pct)
+			}
+			posteriors[p] = PartialUpdateRating(priors[i].PlayerRating(p), post, pct)
+		}
+	}
+}
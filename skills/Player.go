@@ -0,0 +1,61 @@
+package skills
+
+import (
+	"fmt"
+)
+
+const (
+	defaultPartialPlayPercentage   = 1.0 // = 100% play time
+	defaultPartialUpdatePercentage = 1.0 // = receive 100% update
+)
+
+// A Player is a convenient player key for teams. Any comparable value can be
+// used as a player, but a Player also carries how much of the match it
+// played and how much of the rating update it should receive.
+type Player struct {
+	Id            interface{}
+	partialPlay   float64
+	partialUpdate float64
+}
+
+// NewPlayer returns a player that played the whole match.
+func NewPlayer(id interface{}) *Player {
+	return NewPlayerWithPercentages(id, defaultPartialPlayPercentage, defaultPartialUpdatePercentage)
+}
+
+// NewPartialPlayer returns a player that played partialPlay of the match,
+// where 0 means not at all and 1 means the whole match.
+func NewPartialPlayer(id interface{}, partialPlay float64) *Player {
+	return NewPlayerWithPercentages(id, partialPlay, defaultPartialUpdatePercentage)
+}
+
+// NewPartialUpdatePlayer returns a player that played the whole match but
+// receives only partialUpdate of the rating update, where 0 means no update
+// and 1 means the whole update.
+func NewPartialUpdatePlayer(id interface{}, partialUpdate float64) *Player {
+	return NewPlayerWithPercentages(id, defaultPartialPlayPercentage, partialUpdate)
+}
+
+// NewPlayerWithPercentages returns a player with both a partial play and a
+// partial update percentage.
+func NewPlayerWithPercentages(id interface{}, partialPlay, partialUpdate float64) *Player {
+	if partialPlay < 0 || partialPlay > 1 {
+		panic(fmt.Errorf("partialPlay [%v] outside of expected range [0, 1]", partialPlay))
+	}
+	if partialUpdate < 0 || partialUpdate > 1 {
+		panic(fmt.Errorf("partialUpdate [%v] outside of expected range [0, 1]", partialUpdate))
+	}
+	return &Player{id, partialPlay, partialUpdate}
+}
+
+func (p *Player) PartialPlayPercentage() float64 {
+	return p.partialPlay
+}
+
+func (p *Player) PartialUpdatePercentage() float64 {
+	return p.partialUpdate
+}
+
+func (p *Player) String() string {
+	return fmt.Sprint(p.Id)
+}
@@ -4,9 +4,11 @@ type PlayerRatings map[interface{}]Rating
 
 type RatingAccumulator func(r Rating, a float64) float64
 
+// Accum accumulates f over the players sorted by their printed form, so
+// floating point sums come out the same from run to run.
 func (pr PlayerRatings) Accum(f RatingAccumulator) (a float64) {
-	for _, r := range pr {
-		a = f(r, a)
+	for _, p := range orderedPlayers(nil, pr) {
+		a = f(pr[p], a)
 	}
 	return
 }
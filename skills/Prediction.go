@@ -0,0 +1,29 @@
+package skills
+
+// The predicted result of a match between two teams, from the first team's
+// point of view. The probabilities sum to 1.
+type Prediction struct {
+	Win  float64
+	Draw float64
+	Lose float64
+}
+
+// Methods required to predict the results of matches before they are played.
+type Predictor interface {
+	// Predicts the result of a match between exactly two teams.
+	PredictOutcome(gi *GameInfo, teams []Team) Prediction
+
+	// Like PredictOutcome but returns an error instead of panicking on
+	// invalid input.
+	TryPredictOutcome(gi *GameInfo, teams []Team) (Prediction, error)
+
+	// Predicts where each team will finish. Element [i][k] is the
+	// probability that teams[i] finishes in position k+1, where a team's
+	// position is one more than the number of teams that beat it, so tied
+	// teams share a position.
+	PredictRanks(gi *GameInfo, teams []Team) [][]float64
+
+	// Like PredictRanks but returns an error instead of panicking on
+	// invalid input.
+	TryPredictRanks(gi *GameInfo, teams []Team) ([][]float64, error)
+}
@@ -1,19 +1,27 @@
 package skills
 
-import (
-	"fmt"
-)
-
 type RankedTeams struct {
 	teams []Team
 	ranks []int
 }
 
+// NewRankedTeams pairs teams with their ranks. It panics if the lengths
+// differ.
 func NewRankedTeams(teams []Team, ranks []int) *RankedTeams {
+	rt, err := TryNewRankedTeams(teams, ranks)
+	if err != nil {
+		panic(err)
+	}
+	return rt
+}
+
+// TryNewRankedTeams pairs teams with their ranks, returning a *RankCountError
+// if the lengths differ.
+func TryNewRankedTeams(teams []Team, ranks []int) (*RankedTeams, error) {
 	if len(teams) != len(ranks) {
-		panic(fmt.Errorf("Number of teams [%v] does not match number of ranks [%v]", len(teams), len(ranks)))
+		return nil, &RankCountError{len(teams), len(ranks)}
 	}
-	return &RankedTeams{teams, ranks}
+	return &RankedTeams{teams, ranks}, nil
 }
 
 func (rt *RankedTeams) AddTeam(team Team, rank int) {
@@ -0,0 +1,43 @@
+package skills
+
+// The points each team scored in a match, in the same order as the teams.
+// Higher scores are better. Calculators that implement ScoreCalc use the
+// margins between teams as well as their order.
+type Scores []float64
+
+// Returns the ranks the scores imply, for calculators that only use ranks.
+// Equal scores are ties.
+func (s Scores) Ranks() []int {
+	ranks := make([]int, len(s))
+	for i, si := range s {
+		ranks[i] = 1
+		for _, sj := range s {
+			if sj > si {
+				ranks[i]++
+			}
+		}
+	}
+	return ranks
+}
+
+// Validate returns a *ScoreError for the first score that isn't finite, or
+// nil if all of them are.
+func (s Scores) Validate() error {
+	for i, si := range s {
+		if !isFinite(si) {
+			return &ScoreError{i, si}
+		}
+	}
+	return nil
+}
+
+// Methods required to calculate skills from scores.
+type ScoreCalc interface {
+	// Calculates new ratings based on the prior ratings and the points each
+	// team scored.
+	CalcNewRatingsFromScores(gi *GameInfo, priors []Team, scores Scores) PlayerRatings
+
+	// Like CalcNewRatingsFromScores but returns an error instead of
+	// panicking on invalid input.
+	TryCalcNewRatingsFromScores(gi *GameInfo, priors []Team, scores Scores) (PlayerRatings, error)
+}
@@ -0,0 +1,23 @@
+package skills
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScoresRanks(t *testing.T) {
+	for _, tc := range []struct {
+		scores Scores
+		want   []int
+	}{
+		{Scores{3, 1}, []int{1, 2}},
+		{Scores{1, 3}, []int{2, 1}},
+		{Scores{3, 1, 3}, []int{1, 3, 1}},
+		{Scores{2, 2, 2}, []int{1, 1, 1}},
+		{Scores{-1, 0, 7, 0}, []int{4, 2, 1, 2}},
+	} {
+		if got := tc.scores.Ranks(); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%v.Ranks() = %v, want %v", tc.scores, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package skills
+
+import (
+	"sync"
+)
+
+// A Store keeps each player's current rating between matches. Players are
+// the store's keys, so they must be comparable and the same player must be
+// the same value from match to match. Implementations are safe for
+// concurrent use.
+type Store interface {
+	// Get returns player p's rating and whether p has one.
+	Get(p interface{}) (Rating, bool, error)
+
+	// GetMany returns the ratings of those of players that have one.
+	GetMany(players ...interface{}) (PlayerRatings, error)
+
+	// Put sets player p's rating.
+	Put(p interface{}, r Rating) error
+
+	// CompareAndSwap sets every rating in new, but only if each player in
+	// old still has the rating given there and each player in new but not
+	// in old has no rating yet. It reports whether it made the change,
+	// which it makes all at once or not at all.
+	CompareAndSwap(old, new PlayerRatings) (bool, error)
+}
+
+// A MemoryStore is a Store that keeps its ratings in memory.
+type MemoryStore struct {
+	mu      sync.Mutex
+	ratings PlayerRatings
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{ratings: make(PlayerRatings)}
+}
+
+func (s *MemoryStore) Get(p interface{}) (Rating, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.ratings[p]
+	return r, ok, nil
+}
+
+func (s *MemoryStore) GetMany(players ...interface{}) (PlayerRatings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return getMany(s.ratings, players), nil
+}
+
+func (s *MemoryStore) Put(p interface{}, r Rating) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ratings[p] = r
+	return nil
+}
+
+func (s *MemoryStore) CompareAndSwap(old, new PlayerRatings) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !unchanged(s.ratings, old, new) {
+		return false, nil
+	}
+	for p, r := range new {
+		s.ratings[p] = r
+	}
+	return true, nil
+}
+
+func getMany(ratings PlayerRatings, players []interface{}) PlayerRatings {
+	found := make(PlayerRatings, len(players))
+	for _, p := range players {
+		if r, ok := ratings[p]; ok {
+			found[p] = r
+		}
+	}
+	return found
+}
+
+// Reports whether ratings still holds what a CompareAndSwap from old to new
+// expects.
+func unchanged(ratings, old, new PlayerRatings) bool {
+	for p, want := range old {
+		if r, ok := ratings[p]; !ok || r != want {
+			return false
+		}
+	}
+	for p := range new {
+		if _, ok := old[p]; ok {
+			continue
+		}
+		if _, ok := ratings[p]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// RateMatch rates one match against the ratings in s: it loads the players'
+// ratings, starting players without one at gi's default rating, calculates
+// their new ratings with calc and stores them. If another writer changes any
+// of the players' ratings in the meantime it starts over with the new
+// ratings, so concurrent matches never overwrite each other's updates.
+//
+// teams holds the players on each team and ranks is as for Calc. It returns
+// the new ratings.
+func RateMatch(s Store, calc TryCalc, gi *GameInfo, teams [][]interface{}, ranks ...int) (PlayerRatings, error) {
+	if err := gi.Validate(); err != nil {
+		return nil, err
+	}
+
+	var players []interface{}
+	for _, t := range teams {
+		players = append(players, t...)
+	}
+
+	for {
+		priors, err := s.GetMany(players...)
+		if err != nil {
+			return nil, err
+		}
+
+		steams := make([]Team, len(teams))
+		for i, t := range teams {
+			steams[i] = NewTeam()
+			for _, p := range t {
+				r, ok := priors[p]
+				if !ok {
+					r = gi.DefaultRating()
+				}
+				steams[i].AddPlayer(p, r)
+			}
+		}
+
+		newRatings, err := calc.TryCalcNewRatings(gi, steams, ranks...)
+		if err != nil {
+			return nil, err
+		}
+
+		ok, err := s.CompareAndSwap(priors, newRatings)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return newRatings, nil
+		}
+	}
+}
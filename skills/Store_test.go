@@ -0,0 +1,199 @@
+package skills
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// Moves each player's mean up one per team beaten and down one per team lost
+// to, which is enough to see updates land in a store.
+type countingCalc struct{}
+
+func (countingCalc) TryCalcNewRatings(gi *GameInfo, teams []Team, ranks ...int) (PlayerRatings, error) {
+	if len(ranks) != len(teams) {
+		return nil, &RankCountError{len(teams), len(ranks)}
+	}
+	newSkills := make(PlayerRatings)
+	for i, t := range teams {
+		delta := 0.0
+		for _, rank := range ranks {
+			switch {
+			case rank > ranks[i]:
+				delta++
+			case rank < ranks[i]:
+				delta--
+			}
+		}
+		for _, p := range t.Players() {
+			r := t.PlayerRating(p)
+			newSkills[p] = NewRating(r.Mean()+delta, r.Stddev())
+		}
+	}
+	return newSkills, nil
+}
+
+func (countingCalc) TryCalcMatchQual(gi *GameInfo, teams []Team) (float64, error) {
+	return 0, nil
+}
+
+func TestMemoryStoreCompareAndSwap(t *testing.T) {
+	testCompareAndSwap(t, NewMemoryStore())
+}
+
+func testCompareAndSwap(t *testing.T, s Store) {
+	a, b := NewRating(20, 5), NewRating(30, 4)
+
+	if ok, err := s.CompareAndSwap(nil, PlayerRatings{"a": a}); !ok || err != nil {
+		t.Fatalf("adding a new player = %v, %v, want true", ok, err)
+	}
+	if ok, _ := s.CompareAndSwap(nil, PlayerRatings{"a": b}); ok {
+		t.Errorf("adding a player that has a rating succeeded")
+	}
+	if ok, _ := s.CompareAndSwap(PlayerRatings{"a": b}, PlayerRatings{"a": a}); ok {
+		t.Errorf("swapping from the wrong rating succeeded")
+	}
+	if ok, _ := s.CompareAndSwap(PlayerRatings{"a": a}, PlayerRatings{"a": b, "b": a}); !ok {
+		t.Errorf("swapping from the right rating failed")
+	}
+
+	if err := s.Put(7, a); err != nil {
+		t.Fatal(err)
+	}
+	if r, ok, err := s.Get("a"); r != b || !ok || err != nil {
+		t.Errorf("Get(a) = %v, %v, %v, want %v", r, ok, err, b)
+	}
+	if _, ok, _ := s.Get("c"); ok {
+		t.Errorf("Get(c) found a rating")
+	}
+	got, err := s.GetMany("a", "b", "c", 7)
+	if want := (PlayerRatings{"a": b, "b": a, 7: a}); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("GetMany = %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestRateMatch(t *testing.T) {
+	s := NewMemoryStore()
+	s.Put("a", NewRating(30, 4))
+	gi := DefaultGameInfo()
+
+	got, err := RateMatch(s, countingCalc{}, gi, [][]interface{}{{"a"}, {"b", "c"}}, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := PlayerRatings{
+		"a": NewRating(29, 4),
+		"b": NewRating(gi.InitialMean+1, gi.InitialStddev),
+		"c": NewRating(gi.InitialMean+1, gi.InitialStddev),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RateMatch = %v, want %v", got, want)
+	}
+	if stored, _ := s.GetMany("a", "b", "c"); !reflect.DeepEqual(stored, want) {
+		t.Errorf("stored %v, want %v", stored, want)
+	}
+
+	_, err = RateMatch(s, countingCalc{}, gi, [][]interface{}{{"a"}, {"b"}}, 1)
+	if _, ok := err.(*RankCountError); !ok {
+		t.Errorf("err = %v, want a *RankCountError", err)
+	}
+}
+
+func TestRateMatchConcurrently(t *testing.T) {
+	s := NewMemoryStore()
+	gi := DefaultGameInfo()
+
+	const matches = 50
+	var wg sync.WaitGroup
+	for i := 0; i < matches; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := RateMatch(s, countingCalc{}, gi, [][]interface{}{{"a"}, {"b"}}, 1, 2); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if r, _, _ := s.Get("a"); r.Mean() != gi.InitialMean+matches {
+		t.Errorf("a's mean = %v after %v wins, want %v", r.Mean(), matches, gi.InitialMean+matches)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "ratings")
+
+	s, err := OpenFileStore(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCompareAndSwap(t, s)
+	want, _ := s.GetMany("a", "b", 7)
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Simulate a crash in the middle of a write
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"version":1,"players":[{"player":"a"`)
+	f.Close()
+
+	s, err = OpenFileStore(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := s.GetMany("a", "b", 7); !reflect.DeepEqual(got, want) {
+		t.Errorf("reopened store has %v, want %v", got, want)
+	}
+
+	if err := s.Put("d", NewRating(1, 2)); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	s, err = OpenFileStore(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	if r, ok, _ := s.Get("d"); !ok || r != NewRating(1, 2) {
+		t.Errorf("Get(d) = %v, %v after reopening, want %v", r, ok, NewRating(1, 2))
+	}
+}
+
+func TestFileStoreRejectsChangingKeys(t *testing.T) {
+	type playerID string
+
+	s, err := OpenFileStore(filepath.Join(t.TempDir(), "ratings"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	for _, p := range []interface{}{playerID("alice"), int64(7), 2.0} {
+		if err := s.Put(p, NewRating(1, 2)); err == nil {
+			t.Errorf("Put(%T) succeeded", p)
+		}
+		if ok, err := s.CompareAndSwap(nil, PlayerRatings{p: NewRating(1, 2)}); ok || err == nil {
+			t.Errorf("CompareAndSwap(%T) = %v, %v, want an error", p, ok, err)
+		}
+		if _, ok, _ := s.Get(p); ok {
+			t.Errorf("Get(%T) found a rejected player", p)
+		}
+	}
+}
+
+func TestFileStoreCorrupt(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "ratings")
+	if err := os.WriteFile(name, []byte("not json\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenFileStore(name); err == nil {
+		t.Errorf("OpenFileStore of a corrupt file succeeded")
+	}
+}
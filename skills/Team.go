@@ -1,14 +1,34 @@
 package skills
 
+import (
+	"fmt"
+	"sort"
+)
+
+// A Team is a set of players and their ratings. Players are kept in the order
+// they were added, and every method that visits the players does so in that
+// order, so calculations are reproducible from run to run.
+//
+// Copies of a Team share the same players. Players set directly in
+// PlayerRatings, as in Team{PlayerRatings: m}, come after those added with
+// AddPlayer before them, sorted by their printed form. The zero Team has no
+// players and can't be added to; use NewTeam.
 type Team struct {
 	PlayerRatings
+	order *[]interface{}
 }
 
 func NewTeam() Team {
-	return Team{make(PlayerRatings)}
+	return Team{make(PlayerRatings), new([]interface{})}
 }
 
+// AddPlayer adds p to the team with rating r. Adding a player that is already
+// on the team replaces their rating but keeps their position; a player
+// deleted from the map and added again goes last.
 func (t Team) AddPlayer(p interface{}, r Rating) {
+	if _, ok := t.PlayerRatings[p]; !ok && t.order != nil {
+		addToOrder(t.order, t.PlayerRatings, p)
+	}
 	t.PlayerRatings[p] = r
 }
 
@@ -16,14 +36,74 @@ func (t Team) PlayerCount() int {
 	return len(t.PlayerRatings)
 }
 
+// Players returns the players in the order they were added.
 func (t Team) Players() []interface{} {
-	ps := []interface{}{}
-	for p := range t.PlayerRatings {
-		ps = append(ps, p)
-	}
-	return ps
+	return orderedPlayers(t.order, t.PlayerRatings)
 }
 
 func (t Team) PlayerRating(p interface{}) Rating {
 	return t.PlayerRatings[p]
 }
+
+// Accum accumulates f over the players in the order they were added.
+func (t Team) Accum(f RatingAccumulator) (a float64) {
+	for _, p := range orderedPlayers(t.order, t.PlayerRatings) {
+		a = f(t.PlayerRatings[p], a)
+	}
+	return
+}
+
+// Appends p, which isn't in ratings, to order. First order is brought up to
+// date with ratings, as for Players, so players set in the map directly come
+// before p and a stale place of p's can't come back, and order doesn't grow
+// as players come and go.
+func addToOrder[K comparable](order *[]K, ratings map[K]Rating, p K) {
+	*order = append(orderedPlayers(order, ratings), p)
+}
+
+// Returns the players in ratings: first those in order, then any others,
+// which were set in the map directly, sorted by their printed form.
+func orderedPlayers[K comparable](order *[]K, ratings map[K]Rating) []K {
+	players := make([]K, 0, len(ratings))
+	seen := make(map[K]bool, len(ratings))
+	if order != nil {
+		for _, p := range *order {
+			if _, ok := ratings[p]; ok && !seen[p] {
+				players = append(players, p)
+				seen[p] = true
+			}
+		}
+	}
+	if len(players) == len(ratings) {
+		return players
+	}
+
+	var rest []K
+	for p := range ratings {
+		if !seen[p] {
+			rest = append(rest, p)
+		}
+	}
+	sortByPrint(rest)
+	return append(players, rest...)
+}
+
+// Sorts players by their printed form, so the order doesn't depend on map
+// iteration. Players that print alike, such as values of a type whose String
+// method ignores some fields, are ordered by their Go syntax representation.
+// Players alike in both, such as pointers to equal values, have no
+// reproducible order, so they are best added with AddPlayer.
+func sortByPrint[K comparable](players []K) {
+	type printed struct{ v, goSyntax string }
+	keys := make(map[K]printed, len(players))
+	for _, p := range players {
+		keys[p] = printed{fmt.Sprintf("%T %v", p, p), fmt.Sprintf("%#v", p)}
+	}
+	sort.SliceStable(players, func(i, j int) bool {
+		a, b := keys[players[i]], keys[players[j]]
+		if a.v != b.v {
+			return a.v < b.v
+		}
+		return a.goSyntax < b.goSyntax
+	})
+}
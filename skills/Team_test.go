@@ -0,0 +1,127 @@
+package skills
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTeamPlayerOrder(t *testing.T) {
+	team := NewTeam()
+	for _, p := range []interface{}{"c", "a", 2, "b", 1} {
+		team.AddPlayer(p, DefaultGameInfo().DefaultRating())
+	}
+
+	// Replacing a rating keeps the player's position
+	team.AddPlayer("a", NewRating(30, 5))
+
+	want := []interface{}{"c", "a", 2, "b", 1}
+	if got := team.Players(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Players() = %v, want %v", got, want)
+	}
+	if got := team.PlayerCount(); got != len(want) {
+		t.Errorf("PlayerCount() = %v, want %v", got, len(want))
+	}
+	if got := team.PlayerRating("a"); got != NewRating(30, 5) {
+		t.Errorf("PlayerRating(a) = %v, want %v", got, NewRating(30, 5))
+	}
+
+	// Copies share players
+	cp := team
+	cp.AddPlayer("d", DefaultGameInfo().DefaultRating())
+	if got := team.PlayerCount(); got != len(want)+1 {
+		t.Errorf("PlayerCount() = %v, want %v", got, len(want)+1)
+	}
+}
+
+func TestTeamOfPlayerOrder(t *testing.T) {
+	team := NewTeamOf[string]()
+	for _, p := range []string{"c", "a", "b"} {
+		team.AddPlayer(p, DefaultGameInfo().DefaultRating())
+	}
+
+	want := []string{"c", "a", "b"}
+	if got := team.Players(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Players() = %v, want %v", got, want)
+	}
+
+	wantUntyped := []interface{}{"c", "a", "b"}
+	if got := team.Team().Players(); !reflect.DeepEqual(got, wantUntyped) {
+		t.Errorf("Team().Players() = %v, want %v", got, wantUntyped)
+	}
+}
+
+func TestTeamWithoutOrder(t *testing.T) {
+	var zero Team
+	if got := zero.Players(); len(got) != 0 {
+		t.Errorf("zero Team Players() = %v, want none", got)
+	}
+	if got := zero.Accum(func(r Rating, a float64) float64 { return a + 1 }); got != 0 {
+		t.Errorf("zero Team Accum = %v, want 0", got)
+	}
+	if _, err := zero.MarshalJSON(); err != nil {
+		t.Errorf("zero Team MarshalJSON: %v", err)
+	}
+
+	// A literal team lists its players in a reproducible order
+	literal := Team{PlayerRatings: PlayerRatings{"b": NewRating(1, 1), "a": NewRating(2, 1), 3: NewRating(3, 1)}}
+	literal.AddPlayer("c", NewRating(4, 1))
+	want := []interface{}{3, "a", "b", "c"}
+	if got := literal.Players(); !reflect.DeepEqual(got, want) {
+		t.Errorf("literal Team Players() = %v, want %v", got, want)
+	}
+}
+
+// Prints the same whatever its value.
+type anonymous int
+
+func (anonymous) String() string { return "?" }
+
+func TestTeamSameStringPlayers(t *testing.T) {
+	want := []interface{}{anonymous(1), anonymous(2), anonymous(3)}
+	for i := 0; i < 20; i++ {
+		literal := Team{PlayerRatings: PlayerRatings{anonymous(3): NewRating(1, 1), anonymous(1): NewRating(1, 1), anonymous(2): NewRating(1, 1)}}
+		if got := literal.Players(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Players() = %#v, want %#v", got, want)
+		}
+	}
+}
+
+func TestTeamDirectMapWrites(t *testing.T) {
+	team := NewTeam()
+	team.AddPlayer("b", NewRating(1, 1))
+	team.AddPlayer("a", NewRating(2, 1))
+
+	team.PlayerRatings["d"] = NewRating(3, 1)
+	team.PlayerRatings["c"] = NewRating(4, 1)
+	delete(team.PlayerRatings, "b")
+
+	want := []interface{}{"a", "c", "d"}
+	if got := team.Players(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Players() = %v, want %v", got, want)
+	}
+
+	// Adding back a deleted player lists it once, in its new place
+	team.AddPlayer("b", NewRating(1, 1))
+	want = []interface{}{"a", "c", "d", "b"}
+	if got := team.Players(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Players() = %v, want %v", got, want)
+	}
+
+	// Players coming and going don't grow the order
+	for i := 0; i < 100; i++ {
+		delete(team.PlayerRatings, "b")
+		team.AddPlayer("b", NewRating(1, 1))
+	}
+	if n := len(*team.order); n > team.PlayerCount() {
+		t.Errorf("order holds %v players for a team of %v", n, team.PlayerCount())
+	}
+}
+
+func TestPlayerRatingsAccumOrder(t *testing.T) {
+	pr := PlayerRatings{"c": NewRating(3, 1), "a": NewRating(1, 1), "b": NewRating(2, 1)}
+	for i := 0; i < 20; i++ {
+		if got := pr.Accum(func(r Rating, a float64) float64 { return 10*a + r.Mean() }); got != 123 {
+			t.Fatalf("Accum visited the players in the order %v, want 123", got)
+		}
+	}
+}
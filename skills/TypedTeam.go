@@ -0,0 +1,112 @@
+package skills
+
+// A PlayerRatingsOf maps players of type K to their ratings. It is the type
+// safe counterpart of PlayerRatings.
+type PlayerRatingsOf[K comparable] map[K]Rating
+
+// A TeamOf is a team whose players are of type K. It is the type safe
+// counterpart of Team and likewise keeps its players in the order they were
+// added, with the same rules for players set directly in the map.
+type TeamOf[K comparable] struct {
+	PlayerRatingsOf[K]
+	order *[]K
+}
+
+func NewTeamOf[K comparable]() TeamOf[K] {
+	return TeamOf[K]{make(PlayerRatingsOf[K]), new([]K)}
+}
+
+// AddPlayer adds p to the team with rating r. Adding a player that is already
+// on the team replaces their rating but keeps their position; a player
+// deleted from the map and added again goes last.
+func (t TeamOf[K]) AddPlayer(p K, r Rating) {
+	if _, ok := t.PlayerRatingsOf[p]; !ok && t.order != nil {
+		addToOrder(t.order, t.PlayerRatingsOf, p)
+	}
+	t.PlayerRatingsOf[p] = r
+}
+
+func (t TeamOf[K]) PlayerCount() int {
+	return len(t.PlayerRatingsOf)
+}
+
+// Players returns the players in the order they were added.
+func (t TeamOf[K]) Players() []K {
+	return orderedPlayers(t.order, t.PlayerRatingsOf)
+}
+
+func (t TeamOf[K]) PlayerRating(p K) Rating {
+	return t.PlayerRatingsOf[p]
+}
+
+// Team returns an untyped copy of t for use with a Calc.
+func (t TeamOf[K]) Team() Team {
+	u := NewTeam()
+	for _, p := range orderedPlayers(t.order, t.PlayerRatingsOf) {
+		u.AddPlayer(p, t.PlayerRatingsOf[p])
+	}
+	return u
+}
+
+// A CalcOf is the type safe counterpart of Calc and TryCalc for players of
+// type K.
+type CalcOf[K comparable] interface {
+	CalcNewRatings(gi *GameInfo, priors []TeamOf[K], teamRanks ...int) PlayerRatingsOf[K]
+	CalcMatchQual(gi *GameInfo, teams []TeamOf[K]) float64
+	TryCalcNewRatings(gi *GameInfo, priors []TeamOf[K], teamRanks ...int) (PlayerRatingsOf[K], error)
+	TryCalcMatchQual(gi *GameInfo, teams []TeamOf[K]) (float64, error)
+}
+
+// NewCalcOf adapts calc to players of type K, for example
+//
+//	calc := skills.NewCalcOf[string](&trueskill.FactorGraphCalc{})
+func NewCalcOf[K comparable](calc interface {
+	Calc
+	TryCalc
+}) CalcOf[K] {
+	return &typedCalc[K]{calc}
+}
+
+type typedCalc[K comparable] struct {
+	calc interface {
+		Calc
+		TryCalc
+	}
+}
+
+func (c *typedCalc[K]) CalcNewRatings(gi *GameInfo, priors []TeamOf[K], teamRanks ...int) PlayerRatingsOf[K] {
+	return typedRatings[K](c.calc.CalcNewRatings(gi, untypedTeams(priors), teamRanks...))
+}
+
+func (c *typedCalc[K]) CalcMatchQual(gi *GameInfo, teams []TeamOf[K]) float64 {
+	return c.calc.CalcMatchQual(gi, untypedTeams(teams))
+}
+
+func (c *typedCalc[K]) TryCalcNewRatings(gi *GameInfo, priors []TeamOf[K], teamRanks ...int) (PlayerRatingsOf[K], error) {
+	prs, err := c.calc.TryCalcNewRatings(gi, untypedTeams(priors), teamRanks...)
+	if err != nil {
+		return nil, err
+	}
+	return typedRatings[K](prs), nil
+}
+
+func (c *typedCalc[K]) TryCalcMatchQual(gi *GameInfo, teams []TeamOf[K]) (float64, error) {
+	return c.calc.TryCalcMatchQual(gi, untypedTeams(teams))
+}
+
+func untypedTeams[K comparable](teams []TeamOf[K]) []Team {
+	ts := make([]Team, len(teams))
+	for i, t := range teams {
+		ts[i] = t.Team()
+	}
+	return ts
+}
+
+// The calculators only return players they were given, so every key is a K.
+func typedRatings[K comparable](prs PlayerRatings) PlayerRatingsOf[K] {
+	trs := make(PlayerRatingsOf[K], len(prs))
+	for p, r := range prs {
+		trs[p.(K)] = r
+	}
+	return trs
+}
@@ -0,0 +1,72 @@
+package skills
+
+import (
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+)
+
+// Validate returns a *GameInfoError for the first field of gi that the
+// calculators can't use, or nil if gi is valid. A nil gi is invalid too.
+func (gi *GameInfo) Validate() error {
+	switch {
+	case gi == nil:
+		return &GameInfoError{}
+	case !isFinite(gi.InitialMean):
+		return &GameInfoError{"InitialMean", gi.InitialMean}
+	case !(gi.InitialStddev > 0) || !isFinite(gi.InitialStddev):
+		return &GameInfoError{"InitialStddev", gi.InitialStddev}
+	case !(gi.Beta > 0) || !isFinite(gi.Beta):
+		return &GameInfoError{"Beta", gi.Beta}
+	case !(gi.DynamicsFactor >= 0) || !isFinite(gi.DynamicsFactor):
+		return &GameInfoError{"DynamicsFactor", gi.DynamicsFactor}
+	case !(gi.DynamicsPerTime >= 0) || !isFinite(gi.DynamicsPerTime):
+		return &GameInfoError{"DynamicsPerTime", gi.DynamicsPerTime}
+	case !(gi.DrawProbability >= 0 && gi.DrawProbability < 1):
+		return &GameInfoError{"DrawProbability", gi.DrawProbability}
+	}
+	return nil
+}
+
+// ValidateTeams checks everything about a match that a calculator relies on
+// except the ranks: the game info, the number of teams, the number of players
+// on each team and every player's rating and partial play percentage.
+func ValidateTeams(gi *GameInfo, teams []Team, teamsAllowed, playersAllowed numerics.Range) error {
+	if err := gi.Validate(); err != nil {
+		return err
+	}
+
+	if n := len(teams); !teamsAllowed.In(n) {
+		return &TeamCountError{n, teamsAllowed}
+	}
+
+	for i, t := range teams {
+		if n := t.PlayerCount(); !playersAllowed.In(n) {
+			return &PlayerCountError{i, n, playersAllowed}
+		}
+	}
+
+	for _, t := range teams {
+		for _, p := range t.Players() {
+			if r := t.PlayerRating(p); !isValidRating(r) {
+				return &RatingError{p, r}
+			}
+			if pp, ok := p.(PartialPlayer); ok {
+				if pct := pp.PartialPlayPercentage(); !(pct >= 0 && pct <= 1) {
+					return &PartialPlayError{p, pct}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Reports whether r has a finite mean and a finite, positive standard
+// deviation.
+func isValidRating(r Rating) bool {
+	return isFinite(r.Mean()) && r.Stddev() > 0 && isFinite(r.Stddev())
+}
+
+func isFinite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
@@ -0,0 +1,55 @@
+package elo
+
+import (
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+	"runtime"
+	"testing"
+)
+
+const errorTolerance = 0.001
+
+// A player that knows how many games they've played.
+type countedPlayer struct {
+	id    int
+	games int
+}
+
+func (p *countedPlayer) GamesPlayed() int { return p.games }
+
+// Rates a game between two players where the first player's rank is rank1.
+func eloAssert(t *testing.T, calc skills.Calc, gi *skills.GameInfo, p1, p2 interface{}, r1, r2 float64, ranks []int, want1, want2 float64) {
+	team1 := skills.NewTeam()
+	team1.AddPlayer(p1, skills.NewRating(r1, gi.InitialStddev))
+	team2 := skills.NewTeam()
+	team2.AddPlayer(p2, skills.NewRating(r2, gi.InitialStddev))
+
+	newRatings := calc.CalcNewRatings(gi, []skills.Team{team1, team2}, ranks...)
+
+	if got := newRatings[p1].Mean(); math.Abs(got-want1) > errorTolerance {
+		t.Errorf("player 1 mean = %v, want %v\n%v", got, want1, testLoc())
+	}
+	if got := newRatings[p2].Mean(); math.Abs(got-want2) > errorTolerance {
+		t.Errorf("player 2 mean = %v, want %v\n%v", got, want2, testLoc())
+	}
+	if got := newRatings[p1].Stddev(); got != gi.InitialStddev {
+		t.Errorf("player 1 stddev = %v, want %v\n%v", got, gi.InitialStddev, testLoc())
+	}
+}
+
+func matchQual(calc skills.Calc, gi *skills.GameInfo, r1, r2 float64) float64 {
+	team1 := skills.NewTeam()
+	team1.AddPlayer(1, skills.NewRating(r1, gi.InitialStddev))
+	team2 := skills.NewTeam()
+	team2.AddPlayer(2, skills.NewRating(r2, gi.InitialStddev))
+	return calc.CalcMatchQual(gi, []skills.Team{team1, team2})
+}
+
+func testLoc() string {
+	_, file, line, ok := runtime.Caller(2)
+	if ok {
+		return fmt.Sprintf("%v:%v", file, line)
+	}
+	return ""
+}
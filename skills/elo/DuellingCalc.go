@@ -0,0 +1,152 @@
+package elo
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+)
+
+// Calculates ratings for any number of teams of any size by treating the
+// match as a duel between every pair of players on different teams and
+// averaging each player's updates. Duel rates the individual duels; a nil
+// Duel uses a GaussianCalc.
+type DuellingCalc struct {
+	Duel skills.TryCalc
+}
+
+// Calculates new ratings based on the prior ratings and team ranks use 1 for first place, repeat the number for a tie (e.g. 1, 2, 2).
+func (calc *DuellingCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatings but returns an error instead of panicking on invalid input.
+func (calc *DuellingCalc) TryCalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) (skills.PlayerRatings, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, duellingTeamRange, duellingPlayerRange); err != nil {
+		return nil, err
+	}
+	if _, err := skills.TryNewRankedTeams(teams, ranks); err != nil {
+		return nil, err
+	}
+
+	duel := calc.duel()
+
+	// Sum each player's changes over all of their duels. The duel calculator
+	// applies any partial updates, so they aren't applied again here.
+	type deltaSum struct {
+		mean, stddev float64
+		duels        int
+	}
+	deltas := map[interface{}]*deltaSum{}
+
+	for _, team := range teams {
+		for _, p := range team.Players() {
+			deltas[p] = &deltaSum{}
+		}
+	}
+
+	err := forEachDuel(teams, func(i, j int, duelTeams []skills.Team) error {
+		duelRatings, err := duel.TryCalcNewRatings(gi, duelTeams, ranks[i], ranks[j])
+		if err != nil {
+			return err
+		}
+
+		for _, t := range duelTeams {
+			p := t.Players()[0]
+			prior, post := t.PlayerRating(p), duelRatings[p]
+			d := deltas[p]
+			d.mean += post.Mean() - prior.Mean()
+			d.stddev += post.Stddev() - prior.Stddev()
+			d.duels++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	newSkills := make(skills.PlayerRatings)
+	for _, team := range teams {
+		for _, p := range team.Players() {
+			r, d := team.PlayerRating(p), deltas[p]
+			n := float64(d.duels)
+			newSkills[p] = skills.NewRating(r.Mean()+d.mean/n, r.Stddev()+d.stddev/n)
+		}
+	}
+
+	return newSkills, nil
+}
+
+// Calculates the match quality as the quality of the worst matched duel,
+// since a match is only as good as its weakest link.
+func (calc *DuellingCalc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Team) float64 {
+	r, err := calc.TryCalcMatchQual(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcMatchQual but returns an error instead of panicking on invalid input.
+func (calc *DuellingCalc) TryCalcMatchQual(gi *skills.GameInfo, teams []skills.Team) (float64, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, duellingTeamRange, duellingPlayerRange); err != nil {
+		return 0, err
+	}
+
+	duel := calc.duel()
+
+	minQual := 1.0
+	err := forEachDuel(teams, func(i, j int, duelTeams []skills.Team) error {
+		qual, err := duel.TryCalcMatchQual(gi, duelTeams)
+		if err != nil {
+			return err
+		}
+		minQual = math.Min(minQual, qual)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return minQual, nil
+}
+
+// Calls f with a pair of one player teams for every pair of players on
+// different teams, where i and j are the indexes of their teams. It stops at
+// the first error.
+func forEachDuel(teams []skills.Team, f func(i, j int, duelTeams []skills.Team) error) error {
+	for i := 0; i < len(teams); i++ {
+		for j := i + 1; j < len(teams); j++ {
+			for _, p1 := range teams[i].Players() {
+				for _, p2 := range teams[j].Players() {
+					t1 := skills.NewTeam()
+					t1.AddPlayer(p1, teams[i].PlayerRating(p1))
+					t2 := skills.NewTeam()
+					t2.AddPlayer(p2, teams[j].PlayerRating(p2))
+
+					if err := f(i, j, []skills.Team{t1, t2}); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (calc *DuellingCalc) duel() skills.TryCalc {
+	if calc.Duel == nil {
+		return &GaussianCalc{}
+	}
+	return calc.Duel
+}
+
+var (
+	duellingTeamRange   = numerics.AtLeast(2)
+	duellingPlayerRange = numerics.AtLeast(1)
+)
@@ -0,0 +1,59 @@
+package elo
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+	"testing"
+)
+
+func TestDuellingCalc(t *testing.T) {
+	gi := FideGameInfo()
+
+	// Two players is just a single duel
+	eloAssert(t, &DuellingCalc{&FideCalc{}}, gi, 1, 2, 1200, 1500, []int{1, 2}, 1216.980, 1483.020)
+
+	// Two on two: each player's update is the average of their two duels
+	calc := &DuellingCalc{&FideCalc{ConstKFactor(32)}}
+
+	team1 := skills.NewTeam()
+	team1.AddPlayer(1, skills.NewRating(1500, gi.InitialStddev))
+	team1.AddPlayer(2, skills.NewRating(1500, gi.InitialStddev))
+
+	team2 := skills.NewTeam()
+	team2.AddPlayer(3, skills.NewRating(1500, gi.InitialStddev))
+	team2.AddPlayer(4, skills.NewRating(1700, gi.InitialStddev))
+
+	teams := []skills.Team{team1, team2}
+	newRatings := calc.CalcNewRatings(gi, teams, 1, 2)
+
+	// Duels against 3 give +16, duels against 4 give +32*(1-0.240253)
+	assertMean(t, (16+24.312)/2+1500, newRatings[1])
+	assertMean(t, (16+24.312)/2+1500, newRatings[2])
+	assertMean(t, 1500-16, newRatings[3])
+	assertMean(t, 1700-24.312, newRatings[4])
+
+	// The worst duel is 1500 vs 1700
+	if got, want := calc.CalcMatchQual(gi, teams), 2*0.240253; math.Abs(got-want) > errorTolerance {
+		t.Errorf("CalcMatchQual = %v, want %v", got, want)
+	}
+
+	// Three teams where the last two drew
+	team3 := skills.NewTeam()
+	team3.AddPlayer(5, skills.NewRating(1500, gi.InitialStddev))
+	teams = append(teams, team3)
+	newRatings = calc.CalcNewRatings(gi, teams, 1, 2, 2)
+
+	// Player 5 lost to 1 and 2 and drew with 3 and 4
+	assertMean(t, 1500+(-16-16+0+32*(0.5-0.240253))/4, newRatings[5])
+
+	// A rank count mismatch is reported as an error
+	if _, err := calc.TryCalcNewRatings(gi, teams, 1, 2); err == nil {
+		t.Errorf("TryCalcNewRatings with too few ranks succeeded")
+	}
+}
+
+func assertMean(t *testing.T, want float64, actual skills.Rating) {
+	if got := actual.Mean(); math.Abs(got-want) > errorTolerance {
+		t.Errorf("actual.Mean = %v, want %v\n%v", got, want, testLoc())
+	}
+}
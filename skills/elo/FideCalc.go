@@ -0,0 +1,59 @@
+package elo
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+)
+
+// Calculates Elo ratings for two players using the logistic curve FIDE uses
+// for chess. A nil KFactor uses DefaultFideKFactor.
+type FideCalc struct {
+	KFactor KFactor
+}
+
+// Returns the GameInfo FIDE ratings are based on: new players start at 1200
+// and 2*Beta = 400 rating points is a 10:1 odds difference. Elo ignores the
+// standard deviation; it is set only so the GameInfo validates.
+func FideGameInfo() *skills.GameInfo {
+	return &skills.GameInfo{
+		InitialMean:   1200,
+		InitialStddev: 400,
+		Beta:          200,
+	}
+}
+
+// Calculates new ratings based on the prior ratings and team ranks use 1 for first place, repeat the number for a tie (e.g. 1, 2).
+func (calc *FideCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatings but returns an error instead of panicking on invalid input.
+func (calc *FideCalc) TryCalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) (skills.PlayerRatings, error) {
+	k := calc.KFactor
+	if k == nil {
+		k = DefaultFideKFactor()
+	}
+	return twoPlayerCalcNewRatings(gi, k, logisticWinProb, teams, ranks)
+}
+
+// Calculates the match quality as the closeness of the expected score to 50%.
+func (calc *FideCalc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Team) float64 {
+	r, err := calc.TryCalcMatchQual(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcMatchQual but returns an error instead of panicking on invalid input.
+func (calc *FideCalc) TryCalcMatchQual(gi *skills.GameInfo, teams []skills.Team) (float64, error) {
+	return twoPlayerCalcMatchQual(gi, logisticWinProb, teams)
+}
+
+func logisticWinProb(gi *skills.GameInfo, playerRating, opponentRating float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponentRating-playerRating)/(2*gi.Beta)))
+}
@@ -0,0 +1,32 @@
+package elo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFideCalc(t *testing.T) {
+	calc := &FideCalc{}
+	gi := FideGameInfo()
+
+	// Established players below 2400 use K = 20
+	eloAssert(t, calc, gi, 1, 2, 1200, 1500, []int{1, 2}, 1216.980, 1483.020)
+	eloAssert(t, calc, gi, 1, 2, 1200, 1500, []int{1, 1}, 1206.980, 1493.020)
+	eloAssert(t, calc, gi, 1, 2, 1200, 1500, []int{2, 1}, 1196.980, 1503.020)
+
+	// Players at or above 2400 use K = 10
+	eloAssert(t, calc, gi, 1, 2, 2500, 2400, []int{1, 2}, 2503.599, 2396.401)
+
+	// Provisional players use K = 40 for their first 30 games
+	eloAssert(t, calc, gi, &countedPlayer{1, 5}, &countedPlayer{2, 30}, 1200, 1500, []int{1, 2}, 1233.961, 1483.020)
+
+	// A constant K overrides the schedule
+	eloAssert(t, &FideCalc{ConstKFactor(32)}, gi, 1, 2, 1500, 1500, []int{1, 2}, 1516, 1484)
+
+	if got := matchQual(calc, gi, 1500, 1500); got != 1 {
+		t.Errorf("matchQual = %v, want 1", got)
+	}
+	if got := matchQual(calc, gi, 1200, 1500); math.Abs(got-0.302) > errorTolerance {
+		t.Errorf("matchQual = %v, want 0.302", got)
+	}
+}
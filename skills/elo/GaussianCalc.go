@@ -0,0 +1,62 @@
+package elo
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+)
+
+// Calculates Elo ratings for two players using the Gaussian (bell curve)
+// performance model from the TrueSkill paper. A nil KFactor uses
+// GaussianKFactor(gi, DefaultLatestGameWeight).
+type GaussianCalc struct {
+	KFactor KFactor
+}
+
+// The latest game weight that gives chess's traditional K of 24 at FIDE's
+// Beta of 200, and the equivalent K at any other Beta.
+var DefaultLatestGameWeight = 24 / (200 * math.Sqrt(math.Pi))
+
+// Calculates new ratings based on the prior ratings and team ranks use 1 for first place, repeat the number for a tie (e.g. 1, 2).
+func (calc *GaussianCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatings but returns an error instead of panicking on invalid input.
+func (calc *GaussianCalc) TryCalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) (skills.PlayerRatings, error) {
+	// The default K factor depends on gi, so check it first
+	if err := gi.Validate(); err != nil {
+		return nil, err
+	}
+
+	k := calc.KFactor
+	if k == nil {
+		k = GaussianKFactor(gi, DefaultLatestGameWeight)
+	}
+	return twoPlayerCalcNewRatings(gi, k, gaussianWinProb, teams, ranks)
+}
+
+// Calculates the match quality as the closeness of the expected score to 50%.
+func (calc *GaussianCalc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Team) float64 {
+	r, err := calc.TryCalcMatchQual(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcMatchQual but returns an error instead of panicking on invalid input.
+func (calc *GaussianCalc) TryCalcMatchQual(gi *skills.GameInfo, teams []skills.Team) (float64, error) {
+	return twoPlayerCalcMatchQual(gi, gaussianWinProb, teams)
+}
+
+func gaussianWinProb(gi *skills.GameInfo, playerRating, opponentRating float64) float64 {
+	ratingDiff := playerRating - opponentRating
+
+	// See equation 1.1 in the TrueSkill paper
+	return numerics.GaussCumulativeTo(ratingDiff / (math.Sqrt2 * gi.Beta))
+}
@@ -0,0 +1,41 @@
+package elo
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+	"testing"
+)
+
+func TestGaussianCalc(t *testing.T) {
+	calc := &GaussianCalc{}
+	gi := FideGameInfo()
+
+	// The default K is 24 at Beta = 200
+	eloAssert(t, calc, gi, 1, 2, 1500, 1500, []int{1, 2}, 1512, 1488)
+	eloAssert(t, calc, gi, 1, 2, 1500, 1500, []int{1, 1}, 1500, 1500)
+	eloAssert(t, calc, gi, 1, 2, 1200, 1500, []int{1, 2}, 1220.534, 1479.466)
+
+	// The default K scales with Beta
+	gi = skills.DefaultGameInfo()
+	k := float64(GaussianKFactor(gi, DefaultLatestGameWeight))
+	eloAssert(t, calc, gi, 1, 2, 25, 25, []int{1, 2}, 25+k/2, 25-k/2)
+
+	if got := matchQual(calc, gi, 25, 25); got != 1 {
+		t.Errorf("matchQual = %v, want 1", got)
+	}
+	if got := matchQual(calc, FideGameInfo(), 1200, 1500); math.Abs(got-0.289) > errorTolerance {
+		t.Errorf("matchQual = %v, want 0.289", got)
+	}
+}
+
+func TestGaussianCalcNilGameInfo(t *testing.T) {
+	teams := []skills.Team{skills.NewTeam(), skills.NewTeam()}
+	teams[0].AddPlayer(1, skills.NewRating(1500, 1))
+	teams[1].AddPlayer(2, skills.NewRating(1500, 1))
+
+	// The default K factor needs gi, so it must be checked first
+	_, err := (&GaussianCalc{}).TryCalcNewRatings(nil, teams, 1, 2)
+	if _, ok := err.(*skills.GameInfoError); !ok {
+		t.Errorf("err = %v, want a *skills.GameInfoError", err)
+	}
+}
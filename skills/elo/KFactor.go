@@ -0,0 +1,74 @@
+package elo
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+)
+
+// A KFactor determines the largest change in rating a single game can cause.
+type KFactor interface {
+	// Returns K for a player with the given rating who has played
+	// gamesPlayed rated games, or -1 if the number of games is unknown.
+	Value(rating float64, gamesPlayed int) float64
+}
+
+// Implemented by players that know how many rated games they've played, for
+// K-factor schedules such as FIDE's that depend on it.
+type GameCounter interface {
+	GamesPlayed() int
+}
+
+// Returns the number of rated games player p has played, or -1 if p doesn't
+// implement GameCounter.
+func GamesPlayed(p interface{}) int {
+	if gc, ok := p.(GameCounter); ok {
+		return gc.GamesPlayed()
+	}
+	return -1
+}
+
+// A ConstKFactor is the same for every player.
+type ConstKFactor float64
+
+func (k ConstKFactor) Value(rating float64, gamesPlayed int) float64 {
+	return float64(k)
+}
+
+// Returns the K-factor suggested in the TrueSkill paper, α*β*√π, where α
+// weights the latest game relative to the player's history.
+func GaussianKFactor(gi *skills.GameInfo, latestGameWeight float64) ConstKFactor {
+	return ConstKFactor(latestGameWeight * gi.Beta * math.Sqrt(math.Pi))
+}
+
+// A FideKFactor gives new players a large K until they have played enough
+// games, then a smaller K that drops again for strong players.
+type FideKFactor struct {
+	Provisional      float64 // K for a player's first ProvisionalGames games
+	ProvisionalGames int
+	Standard         float64 // K for established players below MasterRating
+	Master           float64 // K for players at or above MasterRating
+	MasterRating     float64
+}
+
+// Returns a new copy of the K-factor schedule FIDE adopted in 2014, so
+// changes to it don't leak into other calculations.
+func DefaultFideKFactor() *FideKFactor {
+	return &FideKFactor{
+		Provisional:      40,
+		ProvisionalGames: 30,
+		Standard:         20,
+		Master:           10,
+		MasterRating:     2400,
+	}
+}
+
+// Players whose game count is unknown are treated as established.
+func (k *FideKFactor) Value(rating float64, gamesPlayed int) float64 {
+	switch {
+	case gamesPlayed >= 0 && gamesPlayed < k.ProvisionalGames:
+		return k.Provisional
+	case rating < k.MasterRating:
+		return k.Standard
+	}
+	return k.Master
+}
@@ -0,0 +1,84 @@
+package elo
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+	"sort"
+)
+
+// The Elo calculators only differ in the curve used to turn a rating
+// difference into a win probability, so they share this implementation.
+type winProbFunc func(gi *skills.GameInfo, playerRating, opponentRating float64) float64
+
+// Elo only tracks a mean. Each player's standard deviation is passed through
+// unchanged so the results can be fed back into any calculator.
+func twoPlayerCalcNewRatings(gi *skills.GameInfo, k KFactor, winProb winProbFunc, teams []skills.Team, ranks []int) (skills.PlayerRatings, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, twoPlayerTeamRange, twoPlayerPlayerRange); err != nil {
+		return nil, err
+	}
+
+	// Copy the slices so we don't confuse the client code
+	steams := append([]skills.Team{}, teams...)
+	sranks := append([]int{}, ranks...)
+
+	// Make sure things are in order; a stable sort keeps tied teams in the
+	// order they were given so results are reproducible
+	rt, err := skills.TryNewRankedTeams(steams, sranks)
+	if err != nil {
+		return nil, err
+	}
+	sort.Stable(rt)
+
+	winner := steams[0].Players()[0]
+	winnerPrevRating := steams[0].PlayerRating(winner)
+
+	loser := steams[1].Players()[0]
+	loserPrevRating := steams[1].PlayerRating(loser)
+
+	wasDraw := sranks[0] == sranks[1]
+
+	newSkills := make(skills.PlayerRatings)
+	newSkills[winner] = twoPlayerCalcNewRating(gi, k, winProb, winner, winnerPrevRating, loserPrevRating, cond(wasDraw, 0.5, 1))
+	newSkills[loser] = twoPlayerCalcNewRating(gi, k, winProb, loser, loserPrevRating, winnerPrevRating, cond(wasDraw, 0.5, 0))
+
+	skills.ApplyPartialUpdates(teams, newSkills)
+
+	return newSkills, nil
+}
+
+func twoPlayerCalcNewRating(gi *skills.GameInfo, k KFactor, winProb winProbFunc, p interface{}, selfRating, oppRating skills.Rating, actualScore float64) skills.Rating {
+	expectedScore := winProb(gi, selfRating.Mean(), oppRating.Mean())
+	kValue := k.Value(selfRating.Mean(), GamesPlayed(p))
+	newMean := selfRating.Mean() + kValue*(actualScore-expectedScore)
+	return skills.NewRating(newMean, selfRating.Stddev())
+}
+
+func twoPlayerCalcMatchQual(gi *skills.GameInfo, winProb winProbFunc, teams []skills.Team) (float64, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, twoPlayerTeamRange, twoPlayerPlayerRange); err != nil {
+		return 0, err
+	}
+
+	p1Rating := teams[0].PlayerRating(teams[0].Players()[0])
+	p2Rating := teams[1].PlayerRating(teams[1].Players()[0])
+
+	// The TrueSkill paper mentions that they used s1 - s2 (rating difference) to
+	// determine match quality. Convert that to a percentage as a delta from 50%
+	// using the cumulative density function of the specific curve being used.
+	deltaFrom50Percent := math.Abs(winProb(gi, p1Rating.Mean(), p2Rating.Mean()) - 0.5)
+	return (0.5 - deltaFrom50Percent) / 0.5, nil
+}
+
+func cond(c bool, t, f float64) float64 {
+	if c {
+		return t
+	}
+	return f
+}
+
+var (
+	twoPlayerTeamRange   = numerics.Exactly(2)
+	twoPlayerPlayerRange = numerics.Exactly(1)
+)
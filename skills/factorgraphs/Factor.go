@@ -0,0 +1,78 @@
+package factorgraphs
+
+import (
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+)
+
+// Methods required of a factor in a factor graph.
+type Factor interface {
+	// Returns the log-normalization constant of the factor.
+	LogNorm() float64
+
+	// Returns the number of messages the factor has.
+	NumMessages() int
+
+	// Updates the message and marginal of the i-th variable the factor is
+	// connected to and returns the change in the marginal.
+	UpdateMessage(i int) float64
+
+	// Resets the marginals of the variables the factor is connected to.
+	ResetMarginals()
+
+	// Sends the i-th message to its variable's marginal and returns the
+	// log-normalization constant.
+	SendMessage(i int) float64
+}
+
+// FactorBase implements the bookkeeping common to all factors. Concrete
+// factors embed it and supply UpdateMessage and, if needed, LogNorm.
+type FactorBase struct {
+	Messages  []*Message
+	Variables []*Variable
+	name      string
+}
+
+func NewFactorBase(name string) FactorBase {
+	return FactorBase{name: "Factor[" + name + "]"}
+}
+
+// LogNorm returns zero; factors with a non-trivial normalization override it.
+func (f *FactorBase) LogNorm() float64 {
+	return 0
+}
+
+func (f *FactorBase) NumMessages() int {
+	return len(f.Messages)
+}
+
+func (f *FactorBase) ResetMarginals() {
+	for _, v := range f.Variables {
+		v.ResetToPrior()
+	}
+}
+
+// SendMessage multiplies the i-th message into its variable's marginal and
+// returns the log-normalization constant of the product.
+func (f *FactorBase) SendMessage(i int) float64 {
+	m, v := f.Messages[i], f.Variables[i]
+	logZ := numerics.LogProdNorm(&v.Value, &m.Value)
+	v.Value.Mul(&v.Value, &m.Value)
+	return logZ
+}
+
+// BindVariable connects v to the factor with an uninformative message and
+// returns the message.
+func (f *FactorBase) BindVariable(v *Variable) *Message {
+	return f.BindVariableMessage(v, NewMessage(*numerics.NewGaussDistFromPrecisionMean(0, 0), "message from %v to %v", f, v))
+}
+
+// BindVariableMessage connects v to the factor through m and returns m.
+func (f *FactorBase) BindVariableMessage(v *Variable, m *Message) *Message {
+	f.Messages = append(f.Messages, m)
+	f.Variables = append(f.Variables, v)
+	return m
+}
+
+func (f *FactorBase) String() string {
+	return f.name
+}
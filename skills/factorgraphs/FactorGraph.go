@@ -0,0 +1,69 @@
+package factorgraphs
+
+// A Layer is a group of factors along with the schedules used to pass
+// messages through them. Either schedule may be nil.
+type Layer interface {
+	Factors() []Factor
+	PriorSchedule() Schedule
+	PosteriorSchedule() Schedule
+}
+
+// LayerBase holds the factors of a layer. Layers embed it and add the
+// variables and schedules they need.
+type LayerBase struct {
+	LocalFactors []Factor
+}
+
+func (l *LayerBase) Factors() []Factor {
+	return l.LocalFactors
+}
+
+func (l *LayerBase) AddFactor(f Factor) {
+	l.LocalFactors = append(l.LocalFactors, f)
+}
+
+func (l *LayerBase) PriorSchedule() Schedule     { return nil }
+func (l *LayerBase) PosteriorSchedule() Schedule { return nil }
+
+// A FactorGraph is a stack of layers. Messages flow down through the prior
+// schedules in order and back up through the posterior schedules in reverse.
+type FactorGraph struct {
+	Layers []Layer
+}
+
+// FullSchedule returns the prior schedules of all layers followed by their
+// posterior schedules in reverse order.
+func (g *FactorGraph) FullSchedule() Schedule {
+	full := []Schedule{}
+
+	for _, l := range g.Layers {
+		if s := l.PriorSchedule(); s != nil {
+			full = append(full, s)
+		}
+	}
+
+	for i := len(g.Layers) - 1; i >= 0; i-- {
+		if s := g.Layers[i].PosteriorSchedule(); s != nil {
+			full = append(full, s)
+		}
+	}
+
+	return NewScheduleSequence("Full schedule", full...)
+}
+
+// RunSchedule runs the full schedule and returns the largest change it caused.
+func (g *FactorGraph) RunSchedule() float64 {
+	return Visit(g.FullSchedule())
+}
+
+// LogNorm returns the log-normalization constant of the whole graph.
+//
+// Note that computing it resets and overwrites the variable marginals, so it
+// should be called only after the results of RunSchedule have been read.
+func (g *FactorGraph) LogNorm() float64 {
+	fl := FactorList{}
+	for _, l := range g.Layers {
+		fl = append(fl, l.Factors()...)
+	}
+	return fl.LogNorm()
+}
@@ -0,0 +1,136 @@
+package factorgraphs
+
+import (
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+	"testing"
+)
+
+const errorTolerance = 0.000001
+
+// A minimal prior factor used to exercise the engine.
+type testPriorFactor struct {
+	FactorBase
+	newMessage numerics.GaussDist
+}
+
+func newTestPriorFactor(mean, stddev float64, v *Variable) *testPriorFactor {
+	f := &testPriorFactor{
+		FactorBase: NewFactorBase("test prior"),
+		newMessage: *numerics.NewGaussDist(mean, stddev),
+	}
+	f.BindVariable(v)
+	return f
+}
+
+func (f *testPriorFactor) UpdateMessage(i int) float64 {
+	m, v := f.Messages[i], f.Variables[i]
+	oldMarginal := v.Value
+	v.Value = *numerics.NewGaussDistFromPrecisionMean(
+		oldMarginal.PrecisionMean+f.newMessage.PrecisionMean-m.Value.PrecisionMean,
+		oldMarginal.Precision+f.newMessage.Precision-m.Value.Precision)
+	m.Value = f.newMessage
+	return numerics.AbsDiff(&oldMarginal, &v.Value)
+}
+
+// A factor that reports a shrinking change each time it is updated.
+type countingFactor struct {
+	FactorBase
+	updates int
+}
+
+func (f *countingFactor) UpdateMessage(i int) float64 {
+	f.updates++
+	return 1 / float64(f.updates)
+}
+
+type testLayer struct {
+	LayerBase
+}
+
+func (l *testLayer) PriorSchedule() Schedule {
+	steps := []Schedule{}
+	for _, f := range l.LocalFactors {
+		steps = append(steps, NewScheduleStep("prior", f, 0))
+	}
+	return NewScheduleSequence("priors", steps...)
+}
+
+func newUninformativeFactory() *VariableFactory {
+	return NewVariableFactory(func() numerics.GaussDist {
+		return *numerics.NewGaussDistFromPrecisionMean(0, 0)
+	})
+}
+
+func TestScheduleStepSendsPrior(t *testing.T) {
+	v := newUninformativeFactory().CreateBasicVariable("skill")
+	f := newTestPriorFactor(25, 3, v)
+
+	Visit(NewScheduleStep("prior", f, 0))
+
+	if math.Abs(v.Value.Mean-25) > errorTolerance || math.Abs(v.Value.Stddev-3) > errorTolerance {
+		t.Errorf("v.Value = %v, want {μ:25 σ:3}", &v.Value)
+	}
+
+	// A second visit must not change the marginal.
+	if delta := Visit(NewScheduleStep("prior", f, 0)); delta > errorTolerance {
+		t.Errorf("second visit delta = %v, want 0", delta)
+	}
+}
+
+func TestScheduleSequenceReturnsMaxDelta(t *testing.T) {
+	f1 := &countingFactor{FactorBase: NewFactorBase("one")}
+	f2 := &countingFactor{FactorBase: NewFactorBase("two"), updates: 3}
+
+	delta := Visit(NewScheduleSequence("seq", NewScheduleStep("a", f1, 0), NewScheduleStep("b", f2, 0)))
+
+	if delta != 1 {
+		t.Errorf("delta = %v, want 1", delta)
+	}
+}
+
+func TestScheduleLoopRunsUntilConverged(t *testing.T) {
+	f := &countingFactor{FactorBase: NewFactorBase("counting")}
+
+	delta := Visit(NewScheduleLoop("loop", NewScheduleStep("step", f, 0), 0.1))
+
+	if f.updates != 10 {
+		t.Errorf("updates = %v, want 10", f.updates)
+	}
+	if delta > 0.1 {
+		t.Errorf("delta = %v, want <= 0.1", delta)
+	}
+}
+
+func TestFactorGraphLogNorm(t *testing.T) {
+	vf := newUninformativeFactory()
+	v := vf.CreateKeyedVariable(1, "%v's skill", 1)
+
+	l := &testLayer{}
+	l.AddFactor(newTestPriorFactor(25, 3, &v.Variable))
+
+	g := &FactorGraph{Layers: []Layer{l}}
+	g.RunSchedule()
+
+	if math.Abs(v.Value.Mean-25) > errorTolerance {
+		t.Errorf("v.Value.Mean = %v, want 25", v.Value.Mean)
+	}
+
+	// A lone prior is a normalized distribution.
+	if logZ := g.LogNorm(); math.Abs(logZ) > errorTolerance {
+		t.Errorf("LogNorm() = %v, want 0", logZ)
+	}
+}
+
+func TestVariableResetToPrior(t *testing.T) {
+	v := NewVariable("x", *numerics.NewGaussDist(1, 2))
+	v.Value = *numerics.NewGaussDist(3, 4)
+	v.ResetToPrior()
+
+	if v.Value.Mean != 1 || v.Value.Stddev != 2 {
+		t.Errorf("v.Value = %v, want {μ:1 σ:2}", &v.Value)
+	}
+	if s := v.String(); s != "Variable[x]" {
+		t.Errorf("v.String() = %q, want %q", s, "Variable[x]")
+	}
+}
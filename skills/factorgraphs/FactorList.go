@@ -0,0 +1,26 @@
+package factorgraphs
+
+// FactorList is a helper for computing a factor graph's normalization constant.
+type FactorList []Factor
+
+// LogNorm resets the marginals of all the factors, sends all of their
+// messages and returns the total log-normalization constant.
+func (fl FactorList) LogNorm() float64 {
+	for _, f := range fl {
+		f.ResetMarginals()
+	}
+
+	sumLogZ := 0.0
+	for _, f := range fl {
+		for j := 0; j < f.NumMessages(); j++ {
+			sumLogZ += f.SendMessage(j)
+		}
+	}
+
+	sumLogS := 0.0
+	for _, f := range fl {
+		sumLogS += f.LogNorm()
+	}
+
+	return sumLogZ + sumLogS
+}
@@ -0,0 +1,20 @@
+package factorgraphs
+
+import (
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+)
+
+// A Message is the value a factor sends to one of its variables.
+type Message struct {
+	Value numerics.GaussDist
+	name  string
+}
+
+func NewMessage(value numerics.GaussDist, nameFormat string, args ...interface{}) *Message {
+	return &Message{value, fmt.Sprintf(nameFormat, args...)}
+}
+
+func (m *Message) String() string {
+	return m.name
+}
@@ -0,0 +1,81 @@
+package factorgraphs
+
+import (
+	"math"
+)
+
+// A Schedule describes the order in which messages are updated in a factor
+// graph. Visit runs the schedule and returns the largest change it caused.
+type Schedule interface {
+	Visit(depth, maxDepth int) float64
+}
+
+// Visit runs s from the top level.
+func Visit(s Schedule) float64 {
+	return s.Visit(-1, 0)
+}
+
+// A ScheduleStep updates a single message of a factor.
+type ScheduleStep struct {
+	Name   string
+	Factor Factor
+	Index  int
+}
+
+func NewScheduleStep(name string, f Factor, index int) *ScheduleStep {
+	return &ScheduleStep{name, f, index}
+}
+
+func (s *ScheduleStep) Visit(depth, maxDepth int) float64 {
+	return s.Factor.UpdateMessage(s.Index)
+}
+
+func (s *ScheduleStep) String() string {
+	return s.Name
+}
+
+// A ScheduleSequence runs a list of schedules in order.
+type ScheduleSequence struct {
+	Name      string
+	Schedules []Schedule
+}
+
+func NewScheduleSequence(name string, schedules ...Schedule) *ScheduleSequence {
+	return &ScheduleSequence{name, schedules}
+}
+
+func (s *ScheduleSequence) Visit(depth, maxDepth int) float64 {
+	maxDelta := 0.0
+	for _, cs := range s.Schedules {
+		maxDelta = math.Max(cs.Visit(depth+1, maxDepth), maxDelta)
+	}
+	return maxDelta
+}
+
+func (s *ScheduleSequence) String() string {
+	return s.Name
+}
+
+// A ScheduleLoop repeats a schedule until the change it causes is no larger
+// than MaxDelta.
+type ScheduleLoop struct {
+	Name     string
+	Schedule Schedule
+	MaxDelta float64
+}
+
+func NewScheduleLoop(name string, s Schedule, maxDelta float64) *ScheduleLoop {
+	return &ScheduleLoop{name, s, maxDelta}
+}
+
+func (s *ScheduleLoop) Visit(depth, maxDepth int) float64 {
+	delta := s.Schedule.Visit(depth+1, maxDepth)
+	for delta > s.MaxDelta {
+		delta = s.Schedule.Visit(depth+1, maxDepth)
+	}
+	return delta
+}
+
+func (s *ScheduleLoop) String() string {
+	return s.Name
+}
@@ -0,0 +1,60 @@
+package factorgraphs
+
+import (
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+)
+
+// A Variable holds the current marginal of a quantity in a factor graph.
+type Variable struct {
+	Value numerics.GaussDist
+	name  string
+	prior numerics.GaussDist
+}
+
+func NewVariable(name string, prior numerics.GaussDist) *Variable {
+	v := &Variable{name: "Variable[" + name + "]", prior: prior}
+	v.ResetToPrior()
+	return v
+}
+
+// ResetToPrior sets the marginal of v back to its prior.
+func (v *Variable) ResetToPrior() {
+	v.Value = v.prior
+}
+
+func (v *Variable) String() string {
+	return v.name
+}
+
+// A KeyedVariable is a Variable associated with a key, usually a player.
+type KeyedVariable struct {
+	Variable
+	Key interface{}
+}
+
+func NewKeyedVariable(key interface{}, name string, prior numerics.GaussDist) *KeyedVariable {
+	kv := &KeyedVariable{Key: key}
+	kv.name = "Variable[" + name + "]"
+	kv.prior = prior
+	kv.ResetToPrior()
+	return kv
+}
+
+// Creates variables that all share the same prior.
+type VariableFactory struct {
+	// using a func to encourage fresh copies in case it's overwritten
+	priorInit func() numerics.GaussDist
+}
+
+func NewVariableFactory(priorInit func() numerics.GaussDist) *VariableFactory {
+	return &VariableFactory{priorInit}
+}
+
+func (vf *VariableFactory) CreateBasicVariable(nameFormat string, args ...interface{}) *Variable {
+	return NewVariable(fmt.Sprintf(nameFormat, args...), vf.priorInit())
+}
+
+func (vf *VariableFactory) CreateKeyedVariable(key interface{}, nameFormat string, args ...interface{}) *KeyedVariable {
+	return NewKeyedVariable(key, fmt.Sprintf(nameFormat, args...), vf.priorInit())
+}
@@ -0,0 +1,101 @@
+package glicko
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+)
+
+// Calculates Glicko ratings for any number of single player teams, such as a
+// one on one game or a free-for-all, by rating every pair of players as a
+// game in a rating period of its own. Use Rate directly to rate many matches
+// in one period.
+type Calc struct{}
+
+// Calculates new ratings based on the prior ratings and team ranks use 1 for first place, repeat the number for a tie (e.g. 1, 2, 2).
+func (calc *Calc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatings but returns an error instead of panicking on invalid input.
+func (calc *Calc) TryCalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) (skills.PlayerRatings, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, calcTeamRange, calcPlayerRange); err != nil {
+		return nil, err
+	}
+	if _, err := skills.TryNewRankedTeams(teams, ranks); err != nil {
+		return nil, err
+	}
+
+	priors := make(skills.PlayerRatings)
+	players := make([]interface{}, len(teams))
+	for i, t := range teams {
+		players[i] = t.Players()[0]
+		priors[players[i]] = t.PlayerRating(players[i])
+	}
+
+	period := NewPeriod()
+	for i := range players {
+		for j := i + 1; j < len(players); j++ {
+			period.AddGame(players[i], players[j], rankScore(ranks[i], ranks[j]))
+		}
+	}
+
+	newSkills := Rate(gi, priors, period)
+	skills.ApplyPartialUpdates(teams, newSkills)
+
+	return newSkills, nil
+}
+
+// Calculates the match quality as the closeness of the expected score of the
+// worst matched pair of players to 50%.
+func (calc *Calc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Team) float64 {
+	r, err := calc.TryCalcMatchQual(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcMatchQual but returns an error instead of panicking on invalid input.
+func (calc *Calc) TryCalcMatchQual(gi *skills.GameInfo, teams []skills.Team) (float64, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, calcTeamRange, calcPlayerRange); err != nil {
+		return 0, err
+	}
+
+	q := math.Ln10 / (2 * gi.Beta)
+	minQual := 1.0
+	for i := range teams {
+		for j := i + 1; j < len(teams); j++ {
+			r1 := teams[i].PlayerRating(teams[i].Players()[0])
+			r2 := teams[j].PlayerRating(teams[j].Players()[0])
+
+			// Glickman's expected outcome accounts for both players' RD
+			gRD := gFunc(q, math.Sqrt(r1.Variance()+r2.Variance()))
+			e := expectedScore(q, gRD, r1.Mean(), r2.Mean())
+			minQual = math.Min(minQual, 1-math.Abs(2*e-1))
+		}
+	}
+
+	return minQual, nil
+}
+
+func rankScore(rank, oppRank int) float64 {
+	switch {
+	case rank < oppRank:
+		return 1
+	case rank > oppRank:
+		return 0
+	}
+	return 0.5
+}
+
+var (
+	calcTeamRange   = numerics.AtLeast(2)
+	calcPlayerRange = numerics.Exactly(1)
+)
@@ -0,0 +1,59 @@
+package glicko
+
+import (
+	"errors"
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+	"testing"
+)
+
+func TestCalc(t *testing.T) {
+	gi := GameInfo()
+	calc := &Calc{}
+
+	teams := make([]skills.Team, 3)
+	for i, r := range []skills.Rating{
+		skills.NewRating(1500, 200),
+		skills.NewRating(1400, 30),
+		skills.NewRating(1700, 300),
+	} {
+		teams[i] = skills.NewTeam()
+		teams[i].AddPlayer(i, r)
+	}
+
+	// A free-for-all is the same as every pair playing in one period
+	newRatings := calc.CalcNewRatings(gi, teams, 1, 3, 2)
+
+	period := NewPeriod()
+	period.AddGame(0, 1, 1)
+	period.AddGame(0, 2, 1)
+	period.AddGame(2, 1, 1)
+	want := Rate(gi, skills.PlayerRatings{
+		0: teams[0].PlayerRating(0),
+		1: teams[1].PlayerRating(1),
+		2: teams[2].PlayerRating(2),
+	}, period)
+
+	for p, r := range want {
+		assertRating(t, r.Mean(), r.Stddev(), newRatings[p], 1e-9)
+	}
+
+	// Evenly matched players are a perfect match
+	even := []skills.Team{skills.NewTeam(), skills.NewTeam()}
+	even[0].AddPlayer(1, gi.DefaultRating())
+	even[1].AddPlayer(2, gi.DefaultRating())
+	if got := calc.CalcMatchQual(gi, even); math.Abs(got-1) > 1e-9 {
+		t.Errorf("CalcMatchQual = %v, want 1", got)
+	}
+	if got := calc.CalcMatchQual(gi, teams); got >= 1 || got <= 0 {
+		t.Errorf("CalcMatchQual = %v, want between 0 and 1", got)
+	}
+
+	// Teams must have one player
+	teams[0].AddPlayer(3, gi.DefaultRating())
+	_, err := calc.TryCalcNewRatings(gi, teams, 1, 2, 3)
+	var e *skills.PlayerCountError
+	if !errors.As(err, &e) {
+		t.Errorf("err = %v, want %T", err, e)
+	}
+}
@@ -0,0 +1,78 @@
+package glicko
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+)
+
+// Returns a GameInfo with the parameters from Glickman's Glicko papers.
+// InitialMean and InitialStddev are a new player's rating and rating
+// deviation (RD), and no RD grows past InitialStddev. DynamicsFactor is c,
+// the RD increase per rating period; this value takes an RD of 50 back to 350
+// after 100 periods without games. 2*Beta is the 400 rating points that give
+// 10:1 odds.
+func GameInfo() *skills.GameInfo {
+	return &skills.GameInfo{
+		InitialMean:    1500,
+		InitialStddev:  350,
+		Beta:           200,
+		DynamicsFactor: math.Sqrt((350*350 - 50*50) / 100.0),
+	}
+}
+
+// Rate applies Glicko to one rating period. A rating's mean is the Glicko
+// rating and its standard deviation is the RD. Players in priors who didn't
+// play only have their RD increased; players in the period who aren't in
+// priors start with gi's default rating.
+func Rate(gi *skills.GameInfo, priors skills.PlayerRatings, period *Period) skills.PlayerRatings {
+	// Step 1: increase every RD for the time since the last period
+	start := make(skills.PlayerRatings)
+	for p, r := range priors {
+		start[p] = inflateRD(gi, r)
+	}
+	for _, p := range period.order {
+		if _, ok := start[p]; !ok {
+			start[p] = gi.DefaultRating()
+		}
+	}
+
+	// Step 2: update everyone who played against the start of period ratings
+	q := math.Ln10 / (2 * gi.Beta)
+	posts := make(skills.PlayerRatings)
+	for p, r := range start {
+		games := period.games[p]
+		if len(games) == 0 {
+			posts[p] = r
+			continue
+		}
+
+		dInvSqr, sum := 0.0, 0.0
+		for _, g := range games {
+			opp := start[g.opponent]
+			gRD := gFunc(q, opp.Stddev())
+			e := expectedScore(q, gRD, r.Mean(), opp.Mean())
+			dInvSqr += gRD * gRD * e * (1 - e)
+			sum += gRD * (g.score - e)
+		}
+		dInvSqr *= q * q
+
+		precision := 1/r.Variance() + dInvSqr
+		posts[p] = skills.NewRating(r.Mean()+q/precision*sum, math.Sqrt(1/precision))
+	}
+
+	return posts
+}
+
+func inflateRD(gi *skills.GameInfo, r skills.Rating) skills.Rating {
+	rd := math.Min(math.Sqrt(r.Variance()+gi.DynamicsFactor*gi.DynamicsFactor), gi.InitialStddev)
+	return skills.NewRating(r.Mean(), rd)
+}
+
+// Reduces the impact of a game according to the opponent's RD.
+func gFunc(q, rd float64) float64 {
+	return 1 / math.Sqrt(1+3*q*q*rd*rd/(math.Pi*math.Pi))
+}
+
+func expectedScore(q, gRD, r, oppR float64) float64 {
+	return 1 / (1 + math.Exp(-q*gRD*(r-oppR)))
+}
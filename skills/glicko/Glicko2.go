@@ -0,0 +1,136 @@
+package glicko
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+)
+
+// A Glicko2Rating adds the Glicko-2 volatility, the expected fluctuation in
+// a player's rating, to a rating and RD.
+type Glicko2Rating struct {
+	skills.Rating
+	Volatility float64
+}
+
+// Glicko2 holds the Glicko-2 parameters that aren't in a GameInfo.
+type Glicko2 struct {
+	// Constrains the change in volatility over time. Glickman suggests
+	// values between 0.3 and 1.2.
+	Tau float64
+
+	// The volatility of a new player.
+	InitialVolatility float64
+
+	// The convergence tolerance of the volatility iteration.
+	Epsilon float64
+}
+
+// Returns a new copy of the parameters from Glickman's Glicko-2 example, so
+// changes to them don't leak into other calculations.
+func DefaultGlicko2() *Glicko2 {
+	return &Glicko2{
+		Tau:               0.5,
+		InitialVolatility: 0.06,
+		Epsilon:           0.000001,
+	}
+}
+
+// DefaultRating returns a new player's rating.
+func (g2 *Glicko2) DefaultRating(gi *skills.GameInfo) Glicko2Rating {
+	return Glicko2Rating{gi.DefaultRating(), g2.InitialVolatility}
+}
+
+// Rate applies Glicko-2 to one rating period. gi supplies the initial rating
+// and RD and the scale (2*Beta rating points give 10:1 odds); its
+// DynamicsFactor is not used since Glicko-2 replaces c with the volatility.
+// Players in priors who didn't play only have their RD increased; players in
+// the period who aren't in priors start with the default rating.
+func (g2 *Glicko2) Rate(gi *skills.GameInfo, priors map[interface{}]Glicko2Rating, period *Period) map[interface{}]Glicko2Rating {
+	// Step 2: convert to the Glicko-2 scale
+	scale := 2 * gi.Beta / math.Ln10
+	type g2r struct{ mu, phi, sigma float64 }
+	start := map[interface{}]g2r{}
+	toG2 := func(r Glicko2Rating) g2r {
+		return g2r{(r.Mean() - gi.InitialMean) / scale, r.Stddev() / scale, r.Volatility}
+	}
+	for p, r := range priors {
+		start[p] = toG2(r)
+	}
+	for _, p := range period.order {
+		if _, ok := start[p]; !ok {
+			start[p] = toG2(g2.DefaultRating(gi))
+		}
+	}
+
+	posts := make(map[interface{}]Glicko2Rating, len(start))
+	for p, r := range start {
+		games := period.games[p]
+
+		var mu, phi, sigma float64
+		if len(games) == 0 {
+			// Only the deviation changes for players who didn't play
+			mu, phi, sigma = r.mu, math.Sqrt(r.phi*r.phi+r.sigma*r.sigma), r.sigma
+		} else {
+			// Steps 3 and 4: the estimated variance and improvement
+			vInv, sum := 0.0, 0.0
+			for _, g := range games {
+				opp := start[g.opponent]
+				gPhi := gFunc(1, opp.phi)
+				e := expectedScore(1, gPhi, r.mu, opp.mu)
+				vInv += gPhi * gPhi * e * (1 - e)
+				sum += gPhi * (g.score - e)
+			}
+			v := 1 / vInv
+			delta := v * sum
+
+			// Steps 5 to 7: the new volatility, deviation and rating
+			sigma = g2.volatility(r.phi, r.sigma, v, delta)
+			phiStar := math.Sqrt(r.phi*r.phi + sigma*sigma)
+			phi = 1 / math.Sqrt(1/(phiStar*phiStar)+1/v)
+			mu = r.mu + phi*phi*sum
+		}
+
+		// Step 8: convert back to the original scale
+		posts[p] = Glicko2Rating{skills.NewRating(mu*scale+gi.InitialMean, phi*scale), sigma}
+	}
+
+	return posts
+}
+
+// Step 5 of the Glicko-2 algorithm, using the Illinois algorithm to solve for
+// the new volatility.
+func (g2 *Glicko2) volatility(phi, sigma, v, delta float64) float64 {
+	tauSqr := g2.Tau * g2.Tau
+	a := math.Log(sigma * sigma)
+	f := func(x float64) float64 {
+		ex := math.Exp(x)
+		d := phi*phi + v + ex
+		return ex*(delta*delta-phi*phi-v-ex)/(2*d*d) - (x-a)/tauSqr
+	}
+
+	A := a
+	var B float64
+	if delta*delta > phi*phi+v {
+		B = math.Log(delta*delta - phi*phi - v)
+	} else {
+		k := 1.0
+		for f(a-k*g2.Tau) < 0 {
+			k++
+		}
+		B = a - k*g2.Tau
+	}
+
+	fA, fB := f(A), f(B)
+	for math.Abs(B-A) > g2.Epsilon {
+		C := A + (A-B)*fA/(fB-fA)
+		fC := f(C)
+		if fC*fB <= 0 {
+			A, fA = B, fB
+		} else {
+			fA /= 2
+		}
+		B, fB = C, fC
+	}
+
+	return math.Exp(A / 2)
+}
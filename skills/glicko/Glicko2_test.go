@@ -0,0 +1,44 @@
+package glicko
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+	"testing"
+)
+
+func TestGlicko2Rate(t *testing.T) {
+	gi := GameInfo()
+	g2 := DefaultGlicko2()
+
+	priors := map[interface{}]Glicko2Rating{
+		"player": {skills.NewRating(1500, 200), 0.06},
+		"a":      {skills.NewRating(1400, 30), 0.06},
+		"b":      {skills.NewRating(1550, 100), 0.06},
+		"c":      {skills.NewRating(1700, 300), 0.06},
+		"idle":   {skills.NewRating(1500, 100), 0.06},
+	}
+
+	posts := g2.Rate(gi, priors, glickmanPeriod())
+
+	// The worked example in Glickman's Glicko-2 paper
+	assertRating(t, 1464.06, 151.52, posts["player"].Rating, 0.01)
+	if got := posts["player"].Volatility; math.Abs(got-0.05999) > 0.00001 {
+		t.Errorf("Volatility = %v, want 0.05999", got)
+	}
+
+	// Players who didn't play only have their RD increased
+	scale := 2 * gi.Beta / math.Ln10
+	phi := 100 / scale
+	assertRating(t, 1500, math.Sqrt(phi*phi+0.06*0.06)*scale, posts["idle"].Rating, 1e-9)
+	if got := posts["idle"].Volatility; got != 0.06 {
+		t.Errorf("Volatility = %v, want 0.06", got)
+	}
+
+	// New players start with the default rating
+	period := NewPeriod()
+	period.AddGame("new", "player", 0.5)
+	posts = g2.Rate(gi, priors, period)
+	if got := posts["new"].Volatility; math.Abs(got-0.06) > 0.001 {
+		t.Errorf("Volatility = %v, want about 0.06", got)
+	}
+}
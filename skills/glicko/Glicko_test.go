@@ -0,0 +1,85 @@
+package glicko
+
+import (
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+	"runtime"
+	"testing"
+)
+
+// Glickman's example: a 1500 player beats a 1400 player and loses to a 1550
+// and a 1700 player in one rating period.
+func glickmanPeriod() *Period {
+	period := NewPeriod()
+	period.AddGame("player", "a", 1)
+	period.AddGame("b", "player", 1)
+	period.AddGame("player", "c", 0)
+	return period
+}
+
+func TestRate(t *testing.T) {
+	gi := GameInfo()
+	gi.DynamicsFactor = 0 // the example starts after step 1
+
+	priors := skills.PlayerRatings{
+		"player": skills.NewRating(1500, 200),
+		"a":      skills.NewRating(1400, 30),
+		"b":      skills.NewRating(1550, 100),
+		"c":      skills.NewRating(1700, 300),
+		"idle":   skills.NewRating(1500, 100),
+	}
+
+	posts := Rate(gi, priors, glickmanPeriod())
+
+	assertRating(t, 1464.1, 151.4, posts["player"], 0.1)
+
+	// Players who didn't play keep their rating
+	assertRating(t, 1500, 100, posts["idle"], 0)
+}
+
+func TestRateInflatesRD(t *testing.T) {
+	gi := GameInfo()
+	priors := skills.PlayerRatings{
+		"idle":   skills.NewRating(1500, 50),
+		"capped": skills.NewRating(1500, 349),
+	}
+
+	posts := Rate(gi, priors, NewPeriod())
+
+	assertRating(t, 1500, math.Sqrt(50*50+gi.DynamicsFactor*gi.DynamicsFactor), posts["idle"], 1e-9)
+	assertRating(t, 1500, 350, posts["capped"], 1e-9)
+}
+
+func TestRateNewPlayers(t *testing.T) {
+	gi := GameInfo()
+	period := NewPeriod()
+	period.AddGame(1, 2, 1)
+
+	posts := Rate(gi, nil, period)
+
+	// Two new players move apart symmetrically
+	if d := posts[1].Mean() - 1500; d <= 0 || math.Abs(d-(1500-posts[2].Mean())) > 1e-9 {
+		t.Errorf("posts = %v", posts)
+	}
+	if posts[1].Stddev() >= 350 {
+		t.Errorf("posts[1].Stddev() = %v, want < 350", posts[1].Stddev())
+	}
+}
+
+func assertRating(t *testing.T, wantMean, wantStddev float64, actual skills.Rating, tol float64) {
+	if got := actual.Mean(); math.Abs(got-wantMean) > tol {
+		t.Errorf("actual.Mean = %v, want %v\n%v", got, wantMean, testLoc())
+	}
+	if got := actual.Stddev(); math.Abs(got-wantStddev) > tol {
+		t.Errorf("actual.Stddev = %v, want %v\n%v", got, wantStddev, testLoc())
+	}
+}
+
+func testLoc() string {
+	_, file, line, ok := runtime.Caller(2)
+	if ok {
+		return fmt.Sprintf("%v:%v", file, line)
+	}
+	return ""
+}
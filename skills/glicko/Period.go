@@ -0,0 +1,39 @@
+package glicko
+
+// A Period collects the games played during one rating period. Glicko rates
+// every game in a period against the ratings players had when the period
+// started, so the order games are added in doesn't matter.
+type Period struct {
+	games map[interface{}][]game
+	order []interface{} // players in the order they first played
+}
+
+// One game from a single player's point of view.
+type game struct {
+	opponent interface{}
+	score    float64
+}
+
+func NewPeriod() *Period {
+	return &Period{games: map[interface{}][]game{}}
+}
+
+// AddGame records a game between p1 and p2, where score is p1's result: 1 for
+// a win, 0.5 for a draw and 0 for a loss.
+func (pd *Period) AddGame(p1, p2 interface{}, score float64) {
+	pd.add(p1, game{p2, score})
+	pd.add(p2, game{p1, 1 - score})
+}
+
+// Players returns the players who played in the period in the order they
+// first played.
+func (pd *Period) Players() []interface{} {
+	return append([]interface{}{}, pd.order...)
+}
+
+func (pd *Period) add(p interface{}, g game) {
+	if _, ok := pd.games[p]; !ok {
+		pd.order = append(pd.order, p)
+	}
+	pd.games[p] = append(pd.games[p], g)
+}
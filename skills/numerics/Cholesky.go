@@ -0,0 +1,95 @@
+package numerics
+
+import (
+	"fmt"
+	"math"
+)
+
+// A Cholesky is the Cholesky decomposition A = L*Lᵀ of a symmetric positive
+// definite matrix, such as a covariance matrix. It is cheaper and more stable
+// than an LU for those matrices.
+type Cholesky struct {
+	n int
+	l []float64 // lower triangular, row-major
+}
+
+// NewCholesky decomposes the symmetric matrix a. Only the lower triangle of a
+// is read. The second result is false if a is not positive definite.
+func NewCholesky(a *Matrix) (*Cholesky, bool) {
+	if !a.isSquare() {
+		panic(fmt.Errorf("matrix [%vx%v] must be square", a.rows, a.cols))
+	}
+
+	n := a.rows
+	l := make([]float64, n*n)
+	for j := 0; j < n; j++ {
+		d := a.At(j, j)
+		for k := 0; k < j; k++ {
+			d -= l[j*n+k] * l[j*n+k]
+		}
+		if d <= 0 || math.IsNaN(d) {
+			return nil, false
+		}
+		ljj := math.Sqrt(d)
+		l[j*n+j] = ljj
+
+		for i := j + 1; i < n; i++ {
+			s := a.At(i, j)
+			for k := 0; k < j; k++ {
+				s -= l[i*n+k] * l[j*n+k]
+			}
+			l[i*n+j] = s / ljj
+		}
+	}
+
+	return &Cholesky{n, l}, true
+}
+
+// L returns the lower triangular factor.
+func (c *Cholesky) L() *Matrix {
+	return NewMatrix(c.n, c.n, c.l...)
+}
+
+// Determinant returns the determinant of the decomposed matrix.
+func (c *Cholesky) Determinant() float64 {
+	return math.Exp(c.LogDeterminant())
+}
+
+// LogDeterminant returns the natural log of the determinant of the decomposed
+// matrix, which does not overflow for large matrices.
+func (c *Cholesky) LogDeterminant() float64 {
+	sum := 0.0
+	for i := 0; i < c.n; i++ {
+		sum += math.Log(c.l[i*c.n+i])
+	}
+	return 2 * sum
+}
+
+// Solve returns x such that A*x = b.
+func (c *Cholesky) Solve(b *Matrix) *Matrix {
+	if b.rows != c.n {
+		panic(fmt.Errorf("matrix rows [%v] do not match [%v]", b.rows, c.n))
+	}
+
+	n, l := c.n, c.l
+	x := NewMatrix(b.rows, b.cols, b.vals...)
+
+	for j := 0; j < b.cols; j++ {
+		// Solve L*y = b
+		for i := 0; i < n; i++ {
+			for k := 0; k < i; k++ {
+				x.vals[i*b.cols+j] -= l[i*n+k] * x.vals[k*b.cols+j]
+			}
+			x.vals[i*b.cols+j] /= l[i*n+i]
+		}
+		// Solve Lᵀ*x = y
+		for i := n - 1; i >= 0; i-- {
+			for k := i + 1; k < n; k++ {
+				x.vals[i*b.cols+j] -= l[k*n+i] * x.vals[k*b.cols+j]
+			}
+			x.vals[i*b.cols+j] /= l[i*n+i]
+		}
+	}
+
+	return x
+}
@@ -12,7 +12,10 @@ func GaussAt(x float64) float64 {
 }
 
 func GaussCumulativeTo(x float64) float64 {
-	return math.Erf(x/math.Sqrt2)/2 + 0.5
+	// Erfc keeps full relative precision far into the left tail, where
+	// Erf(x)/2 + 0.5 cancels to zero; the truncated Gaussian corrections
+	// divide by this value.
+	return math.Erfc(-x/math.Sqrt2) / 2
 }
 
 func GaussInvCumulativeTo(x, mean, stddev float64) float64 {
@@ -71,6 +74,19 @@ func NewGaussDist(mean, stddev float64) *GaussDist {
 	}
 }
 
+// NewGaussDistFromPrecisionMean returns a Gaussian distribution given its
+// precision mean and precision. A precision of zero yields a distribution with
+// infinite variance, which is used as the uninformative starting message in
+// factor graphs.
+func NewGaussDistFromPrecisionMean(precisionMean, precision float64) *GaussDist {
+	z := &GaussDist{
+		Precision:     precision,
+		PrecisionMean: precisionMean,
+	}
+	z.fromPrecisionMean()
+	return z
+}
+
 func (z *GaussDist) String() string {
 	return fmt.Sprintf("{μ:%.6g σ:%.6g}", z.Mean, z.Stddev)
 }
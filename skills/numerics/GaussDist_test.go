@@ -27,6 +27,14 @@ func TestGaussCumulativeTo(t *testing.T) {
 	})
 }
 
+func TestGaussCumulativeToTail(t *testing.T) {
+	// Far in the left tail the result must keep its relative precision
+	const in, out = -10, 7.61985302416053e-24
+	Convey(fmt.Sprintf("GaussCumulativeTo(%v) should equal %v", in, out), t, func() {
+		So(GaussCumulativeTo(in)/out, ShouldAlmostEqual, 1, errorTolerance)
+	})
+}
+
 func TestGaussInvCumulativeTo(t *testing.T) {
 	const mu, sig, in, out = 0, 1, 0.69146246, 0.5
 	Convey(fmt.Sprintf("GaussInvCumulativeTo(%v, %v, %v) should equal %v", in, mu, sig, out), t, func() {
@@ -205,3 +213,14 @@ func TestAbsDiff(t *testing.T) {
 		})
 	})
 }
+
+func TestNewGaussDistFromPrecisionMean(t *testing.T) {
+	Convey("Given a Gaussian distribution built from its precision mean", t, func() {
+		g := NewGaussDistFromPrecisionMean(2.0/9.0, 1.0/9.0)
+
+		Convey("The mean and standard deviation should match the direct parameterization", func() {
+			So(g.Mean, ShouldAlmostEqual, 2, errorTolerance)
+			So(g.Stddev, ShouldAlmostEqual, 3, errorTolerance)
+		})
+	})
+}
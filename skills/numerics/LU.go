@@ -0,0 +1,114 @@
+package numerics
+
+import (
+	"fmt"
+	"math"
+)
+
+// An LU is the LU decomposition with partial pivoting of a square matrix,
+// P*A = L*U. It gives a numerically stable way to compute determinants,
+// inverses and solutions of linear systems.
+type LU struct {
+	n    int
+	lu   []float64 // L below the diagonal (unit diagonal implied), U on and above
+	piv  []int
+	sign float64
+}
+
+// NewLU decomposes the square matrix a.
+func NewLU(a *Matrix) *LU {
+	if !a.isSquare() {
+		panic(fmt.Errorf("matrix [%vx%v] must be square", a.rows, a.cols))
+	}
+
+	n := a.rows
+	f := &LU{n, append([]float64{}, a.vals...), make([]int, n), 1}
+	for i := range f.piv {
+		f.piv[i] = i
+	}
+
+	lu := f.lu
+	for c := 0; c < n; c++ {
+		// Pick the largest remaining value in the column as the pivot
+		p := c
+		for r := c + 1; r < n; r++ {
+			if math.Abs(lu[r*n+c]) > math.Abs(lu[p*n+c]) {
+				p = r
+			}
+		}
+		if p != c {
+			for k := 0; k < n; k++ {
+				lu[c*n+k], lu[p*n+k] = lu[p*n+k], lu[c*n+k]
+			}
+			f.piv[c], f.piv[p] = f.piv[p], f.piv[c]
+			f.sign = -f.sign
+		}
+
+		pivot := lu[c*n+c]
+		if pivot == 0 {
+			continue
+		}
+		for r := c + 1; r < n; r++ {
+			lu[r*n+c] /= pivot
+			l := lu[r*n+c]
+			for k := c + 1; k < n; k++ {
+				lu[r*n+k] -= l * lu[c*n+k]
+			}
+		}
+	}
+
+	return f
+}
+
+// Singular reports whether the decomposed matrix is singular.
+func (f *LU) Singular() bool {
+	for i := 0; i < f.n; i++ {
+		if f.lu[i*f.n+i] == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Determinant returns the determinant of the decomposed matrix.
+func (f *LU) Determinant() float64 {
+	det := f.sign
+	for i := 0; i < f.n; i++ {
+		det *= f.lu[i*f.n+i]
+	}
+	return det
+}
+
+// Solve returns x such that A*x = b. It panics if A is singular.
+func (f *LU) Solve(b *Matrix) *Matrix {
+	if b.rows != f.n {
+		panic(fmt.Errorf("matrix rows [%v] do not match [%v]", b.rows, f.n))
+	}
+	if f.Singular() {
+		panic(fmt.Errorf("matrix is singular"))
+	}
+
+	n, lu := f.n, f.lu
+	x := NewMatrix(b.rows, b.cols)
+	for i, p := range f.piv {
+		copy(x.vals[i*b.cols:(i+1)*b.cols], b.vals[p*b.cols:(p+1)*b.cols])
+	}
+
+	for j := 0; j < b.cols; j++ {
+		// Forward substitution with L
+		for i := 0; i < n; i++ {
+			for k := 0; k < i; k++ {
+				x.vals[i*b.cols+j] -= lu[i*n+k] * x.vals[k*b.cols+j]
+			}
+		}
+		// Back substitution with U
+		for i := n - 1; i >= 0; i-- {
+			for k := i + 1; k < n; k++ {
+				x.vals[i*b.cols+j] -= lu[i*n+k] * x.vals[k*b.cols+j]
+			}
+			x.vals[i*b.cols+j] /= lu[i*n+i]
+		}
+	}
+
+	return x
+}
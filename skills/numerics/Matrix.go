@@ -0,0 +1,233 @@
+package numerics
+
+import (
+	"fmt"
+	"math"
+)
+
+// A Matrix is a dense matrix of float64 values.
+//
+// Operations follow the same pattern as GaussDist: z.Mul(x, y) sets z to
+// the result and returns z, so new(Matrix).Mul(x, y) allocates a new matrix.
+// The receiver may alias an argument.
+type Matrix struct {
+	rows int
+	cols int
+	vals []float64 // row-major
+}
+
+// NewMatrix returns a rows x cols matrix filled from vals in row-major order.
+// Missing values are zero.
+func NewMatrix(rows, cols int, vals ...float64) *Matrix {
+	if len(vals) > rows*cols {
+		panic(fmt.Errorf("len(vals) [%v] exceeds matrix size [%vx%v]", len(vals), rows, cols))
+	}
+	m := &Matrix{rows, cols, make([]float64, rows*cols)}
+	copy(m.vals, vals)
+	return m
+}
+
+// NewSquareMatrix returns an n x n matrix filled from n*n vals in row-major
+// order.
+func NewSquareMatrix(vals ...float64) *Matrix {
+	n := int(math.Sqrt(float64(len(vals))))
+	if n*n != len(vals) {
+		panic(fmt.Errorf("len(vals) [%v] is not a perfect square", len(vals)))
+	}
+	return NewMatrix(n, n, vals...)
+}
+
+// NewDiagonalMatrix returns a square matrix with diag on its diagonal.
+func NewDiagonalMatrix(diag ...float64) *Matrix {
+	m := NewMatrix(len(diag), len(diag))
+	for i, v := range diag {
+		m.Set(i, i, v)
+	}
+	return m
+}
+
+// NewIdentityMatrix returns the n x n identity matrix.
+func NewIdentityMatrix(n int) *Matrix {
+	m := NewMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m.Set(i, i, 1)
+	}
+	return m
+}
+
+// NewVector returns a column vector holding vals.
+func NewVector(vals ...float64) *Matrix {
+	return NewMatrix(len(vals), 1, vals...)
+}
+
+func (m *Matrix) Rows() int { return m.rows }
+func (m *Matrix) Cols() int { return m.cols }
+
+// At returns the value at row i and column j.
+func (m *Matrix) At(i, j int) float64 {
+	return m.vals[i*m.cols+j]
+}
+
+// Set sets the value at row i and column j.
+func (m *Matrix) Set(i, j int, v float64) {
+	m.vals[i*m.cols+j] = v
+}
+
+func (m *Matrix) String() string {
+	return fmt.Sprintf("%vx%v%v", m.rows, m.cols, m.vals)
+}
+
+func (m *Matrix) isSquare() bool {
+	return m.rows == m.cols && m.rows > 0
+}
+
+// set makes z the rows x cols matrix backed by vals.
+func (z *Matrix) set(rows, cols int, vals []float64) *Matrix {
+	z.rows, z.cols, z.vals = rows, cols, vals
+	return z
+}
+
+// Transpose sets z to the transpose of x and returns z.
+func (z *Matrix) Transpose(x *Matrix) *Matrix {
+	vals := make([]float64, len(x.vals))
+	for i := 0; i < x.rows; i++ {
+		for j := 0; j < x.cols; j++ {
+			vals[j*x.rows+i] = x.At(i, j)
+		}
+	}
+	return z.set(x.cols, x.rows, vals)
+}
+
+// Scale sets z to a*x and returns z.
+func (z *Matrix) Scale(a float64, x *Matrix) *Matrix {
+	vals := make([]float64, len(x.vals))
+	for i, v := range x.vals {
+		vals[i] = a * v
+	}
+	return z.set(x.rows, x.cols, vals)
+}
+
+// Add sets z to the sum x+y and returns z.
+func (z *Matrix) Add(x, y *Matrix) *Matrix {
+	if x.rows != y.rows || x.cols != y.cols {
+		panic(fmt.Errorf("matrix sizes [%vx%v] and [%vx%v] differ", x.rows, x.cols, y.rows, y.cols))
+	}
+	vals := make([]float64, len(x.vals))
+	for i := range vals {
+		vals[i] = x.vals[i] + y.vals[i]
+	}
+	return z.set(x.rows, x.cols, vals)
+}
+
+// Mul sets z to the product x*y and returns z.
+func (z *Matrix) Mul(x, y *Matrix) *Matrix {
+	// Just your standard matrix multiplication.
+	// See http://en.wikipedia.org/wiki/Matrix_multiplication for details
+	if x.cols != y.rows {
+		panic(fmt.Errorf("matrix columns [%v] do not match rows [%v]", x.cols, y.rows))
+	}
+	vals := make([]float64, x.rows*y.cols)
+	for i := 0; i < x.rows; i++ {
+		for j := 0; j < y.cols; j++ {
+			sum := 0.0
+			for k := 0; k < x.cols; k++ {
+				sum += x.At(i, k) * y.At(k, j)
+			}
+			vals[i*y.cols+j] = sum
+		}
+	}
+	return z.set(x.rows, y.cols, vals)
+}
+
+// Determinant returns the determinant of the square matrix m.
+func (m *Matrix) Determinant() float64 {
+	return NewLU(m).Determinant()
+}
+
+// Adjugate sets z to the adjugate of the square matrix x and returns z.
+func (z *Matrix) Adjugate(x *Matrix) *Matrix {
+	if !x.isSquare() {
+		panic(fmt.Errorf("matrix [%vx%v] must be square", x.rows, x.cols))
+	}
+
+	// See http://en.wikipedia.org/wiki/Adjugate_matrix
+	n := x.rows
+	if n == 1 {
+		return z.set(1, 1, []float64{1})
+	}
+
+	// The idea is that it's the transpose of the cofactors
+	vals := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			vals[j*n+i] = x.cofactor(i, j)
+		}
+	}
+	return z.set(n, n, vals)
+}
+
+// Inverse sets z to the inverse of the square matrix x and returns z.
+// It panics if x is singular.
+func (z *Matrix) Inverse(x *Matrix) *Matrix {
+	inv := NewLU(x).Solve(NewIdentityMatrix(x.rows))
+	return z.set(inv.rows, inv.cols, inv.vals)
+}
+
+// Solve sets z to the solution of a*z = b for square a and returns z.
+// It panics if a is singular.
+func (z *Matrix) Solve(a, b *Matrix) *Matrix {
+	sol := NewLU(a).Solve(b)
+	return z.set(sol.rows, sol.cols, sol.vals)
+}
+
+// minor returns m with row i and column j removed.
+func (m *Matrix) minor(i, j int) *Matrix {
+	// See http://en.wikipedia.org/wiki/Minor_(linear_algebra)
+	r := NewMatrix(m.rows-1, m.cols-1)
+	k := 0
+	for mi := 0; mi < m.rows; mi++ {
+		if mi == i {
+			continue
+		}
+		for mj := 0; mj < m.cols; mj++ {
+			if mj == j {
+				continue
+			}
+			r.vals[k] = m.At(mi, mj)
+			k++
+		}
+	}
+	return r
+}
+
+func (m *Matrix) cofactor(i, j int) float64 {
+	// See http://en.wikipedia.org/wiki/Cofactor_(linear_algebra) for details
+	d := m.minor(i, j).Determinant()
+	if (i+j)%2 == 0 {
+		return d
+	}
+	return -d
+}
+
+// Anything smaller than this is assumed to be rounding error by Equal.
+const matrixErrorTolerance = 1e-10
+
+// Equal reports whether m and x have the same size and all of their values
+// are within rounding error of each other.
+func (m *Matrix) Equal(x *Matrix) bool {
+	return m.AlmostEqual(x, matrixErrorTolerance)
+}
+
+// AlmostEqual reports whether m and x have the same size and all of their
+// values differ by no more than tol.
+func (m *Matrix) AlmostEqual(x *Matrix, tol float64) bool {
+	if m.rows != x.rows || m.cols != x.cols {
+		return false
+	}
+	for i, v := range m.vals {
+		if math.Abs(v-x.vals[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,158 @@
+package numerics
+
+import (
+	. "github.com/smartystreets/goconvey/convey"
+	"testing"
+)
+
+func TestTwoByTwoDeterminant(t *testing.T) {
+	Convey("Determinant of 2x2 matrices", t, func() {
+		So(NewSquareMatrix(1, 2, 3, 4).Determinant(), ShouldAlmostEqual, -2, errorTolerance)
+		So(NewSquareMatrix(3, 4, 5, 6).Determinant(), ShouldAlmostEqual, -2, errorTolerance)
+		So(NewSquareMatrix(1, 1, 1, 1).Determinant(), ShouldAlmostEqual, 0, errorTolerance)
+		So(NewSquareMatrix(12, 15, 17, 21).Determinant(), ShouldAlmostEqual, 12*21-15*17, errorTolerance)
+	})
+}
+
+func TestThreeByThreeDeterminant(t *testing.T) {
+	Convey("Determinant of 3x3 matrices", t, func() {
+		So(NewSquareMatrix(1, 2, 3, 4, 5, 6, 7, 8, 9).Determinant(), ShouldAlmostEqual, 0, errorTolerance)
+		So(NewSquareMatrix(3, 1, 4, 1, 5, 9, 2, 6, 5).Determinant(), ShouldAlmostEqual, -90, errorTolerance)
+	})
+}
+
+func TestFourByFourDeterminant(t *testing.T) {
+	Convey("Determinant of 4x4 matrices", t, func() {
+		So(NewSquareMatrix(
+			1, 2, 3, 4,
+			5, 6, 7, 8,
+			9, 10, 11, 12,
+			13, 14, 15, 16).Determinant(), ShouldAlmostEqual, 0, errorTolerance)
+		So(NewSquareMatrix(
+			3, 1, 4, 1,
+			5, 9, 2, 6,
+			5, 3, 5, 8,
+			9, 7, 9, 3).Determinant(), ShouldAlmostEqual, 98, errorTolerance)
+	})
+}
+
+func TestEightByEightDeterminant(t *testing.T) {
+	Convey("Determinant of 8x8 matrices", t, func() {
+		So(NewSquareMatrix(
+			1, 2, 3, 4, 5, 6, 7, 8,
+			9, 10, 11, 12, 13, 14, 15, 16,
+			17, 18, 19, 20, 21, 22, 23, 24,
+			25, 26, 27, 28, 29, 30, 31, 32,
+			33, 34, 35, 36, 37, 38, 39, 40,
+			41, 42, 32, 44, 45, 46, 47, 48,
+			49, 50, 51, 52, 53, 54, 55, 56,
+			57, 58, 59, 60, 61, 62, 63, 64).Determinant(), ShouldAlmostEqual, 0, errorTolerance)
+		So(NewSquareMatrix(
+			3, 1, 4, 1, 5, 9, 2, 6,
+			5, 3, 5, 8, 9, 7, 9, 3,
+			2, 3, 8, 4, 6, 2, 6, 4,
+			3, 3, 8, 3, 2, 7, 9, 5,
+			0, 2, 8, 8, 4, 1, 9, 7,
+			1, 6, 9, 3, 9, 9, 3, 7,
+			5, 1, 0, 5, 8, 2, 0, 9,
+			7, 4, 9, 4, 4, 5, 9, 2).Determinant(), ShouldAlmostEqual, 1378143, 1e-6)
+	})
+}
+
+func TestEquals(t *testing.T) {
+	Convey("Matrix equality", t, func() {
+		a := NewSquareMatrix(1, 2, 3, 4)
+		b := NewSquareMatrix(1, 2, 3, 4)
+		So(a.Equal(b), ShouldBeTrue)
+		So(a.Equal(new(Matrix).Scale(1, b)), ShouldBeTrue)
+
+		c := NewMatrix(2, 3, 1, 2, 3, 4, 5, 6)
+		d := NewMatrix(2, 3, 1, 2, 3, 4, 5, 6)
+		So(c.Equal(d), ShouldBeTrue)
+		So(c.Equal(new(Matrix).Transpose(d)), ShouldBeFalse)
+
+		e := NewMatrix(3, 2, 1, 4, 2, 5, 3, 6)
+		So(e.Equal(new(Matrix).Transpose(c)), ShouldBeTrue)
+		So(NewSquareMatrix(1, 2, 3, 4).Equal(NewSquareMatrix(1, 2, 3, 5)), ShouldBeFalse)
+
+		Convey("Within rounding error", func() {
+			f := NewSquareMatrix(2.00000000000001, 0, 0, 1)
+			g := NewSquareMatrix(2, 0, 0, 1)
+			So(f.Equal(g), ShouldBeTrue)
+			So(NewSquareMatrix(2.1, 0, 0, 1).AlmostEqual(g, 0.2), ShouldBeTrue)
+		})
+	})
+}
+
+func TestSpecialMatrices(t *testing.T) {
+	Convey("Diagonal, identity and vector constructors", t, func() {
+		So(NewDiagonalMatrix(1, 2, 3).Equal(NewSquareMatrix(1, 0, 0, 0, 2, 0, 0, 0, 3)), ShouldBeTrue)
+		So(NewIdentityMatrix(2).Equal(NewSquareMatrix(1, 0, 0, 1)), ShouldBeTrue)
+		v := NewVector(1, 2, 3)
+		So(v.Rows(), ShouldEqual, 3)
+		So(v.Cols(), ShouldEqual, 1)
+		So(func() { NewSquareMatrix(1, 2, 3) }, ShouldPanic)
+	})
+}
+
+func TestAdjugate(t *testing.T) {
+	Convey("Adjugate of a 2x2 matrix", t, func() {
+		a := NewSquareMatrix(1, 2, 3, 4)
+		So(new(Matrix).Adjugate(a).Equal(NewSquareMatrix(4, -2, -3, 1)), ShouldBeTrue)
+	})
+	Convey("Adjugate of a 3x3 matrix", t, func() {
+		a := NewSquareMatrix(-3, 2, -5, -1, 0, -2, 3, -4, 1)
+		So(new(Matrix).Adjugate(a).Equal(NewSquareMatrix(-8, 18, -4, -5, 12, -1, 4, -6, 2)), ShouldBeTrue)
+	})
+}
+
+func TestInverse(t *testing.T) {
+	Convey("Inverse of a 2x2 matrix", t, func() {
+		a := NewSquareMatrix(4, 3, 3, 2)
+		So(new(Matrix).Inverse(a).Equal(NewSquareMatrix(-2, 3, 3, -4)), ShouldBeTrue)
+		So(new(Matrix).Mul(a, new(Matrix).Inverse(a)).Equal(NewIdentityMatrix(2)), ShouldBeTrue)
+	})
+	Convey("Inverse of a 3x3 matrix", t, func() {
+		a := NewSquareMatrix(1, 2, 3, 0, 4, 5, 1, 0, 6)
+		expected := new(Matrix).Scale(1.0/22, NewSquareMatrix(24, -12, -2, 5, 3, -5, -4, 2, 4))
+		inv := new(Matrix).Inverse(a)
+		So(inv.Equal(expected), ShouldBeTrue)
+		So(new(Matrix).Mul(a, inv).Equal(NewIdentityMatrix(3)), ShouldBeTrue)
+		So(new(Matrix).Mul(inv, a).Equal(NewIdentityMatrix(3)), ShouldBeTrue)
+	})
+	Convey("Inverse of a singular matrix panics", t, func() {
+		So(func() { new(Matrix).Inverse(NewSquareMatrix(1, 1, 1, 1)) }, ShouldPanic)
+	})
+}
+
+func TestLU(t *testing.T) {
+	Convey("LU solves a linear system", t, func() {
+		a := NewSquareMatrix(0, 2, 1, 1, 1, 1, 2, 1, 0)
+		b := NewVector(7, 6, 4)
+		lu := NewLU(a)
+		So(lu.Singular(), ShouldBeFalse)
+		So(lu.Determinant(), ShouldAlmostEqual, a.Determinant(), errorTolerance)
+		x := lu.Solve(b)
+		So(x.Equal(NewVector(1, 2, 3)), ShouldBeTrue)
+		So(new(Matrix).Solve(a, b).Equal(x), ShouldBeTrue)
+	})
+	Convey("LU detects a singular matrix", t, func() {
+		So(NewLU(NewSquareMatrix(1, 2, 2, 4)).Singular(), ShouldBeTrue)
+	})
+}
+
+func TestCholesky(t *testing.T) {
+	Convey("Cholesky of a positive definite matrix", t, func() {
+		a := NewSquareMatrix(4, 12, -16, 12, 37, -43, -16, -43, 98)
+		c, ok := NewCholesky(a)
+		So(ok, ShouldBeTrue)
+		So(c.L().Equal(NewSquareMatrix(2, 0, 0, 6, 1, 0, -8, 5, 3)), ShouldBeTrue)
+		So(c.Determinant(), ShouldAlmostEqual, a.Determinant(), errorTolerance)
+		b := NewVector(1, 2, 3)
+		So(c.Solve(b).Equal(new(Matrix).Solve(a, b)), ShouldBeTrue)
+	})
+	Convey("Cholesky rejects a matrix that is not positive definite", t, func() {
+		_, ok := NewCholesky(NewSquareMatrix(1, 2, 2, 1))
+		So(ok, ShouldBeFalse)
+	})
+}
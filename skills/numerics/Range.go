@@ -11,12 +11,31 @@ type Range struct {
 	max int
 }
 
-// Construct a range (closed interval).
+// Returned when a range's minimum is greater than its maximum.
+type RangeError struct {
+	Min int
+	Max int
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("min %v > max %v", e.Min, e.Max)
+}
+
+// Construct a range (closed interval). It panics if min > max.
 func NewRange(min, max int) Range {
+	r, err := TryNewRange(min, max)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Construct a range (closed interval), returning a *RangeError if min > max.
+func TryNewRange(min, max int) (Range, error) {
 	if min > max {
-		panic(fmt.Errorf("min %v > max %v", min, max))
+		return Range{}, &RangeError{min, max}
 	}
-	return Range{min, max}
+	return Range{min, max}, nil
 }
 
 // Construct a range with a minimum value
@@ -1,10 +1,14 @@
 package trueskill
 
 import (
+	"errors"
 	"fmt"
 	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/factorgraphs"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
 	. "github.com/smartystreets/goconvey/convey"
 	"math"
+	"reflect"
 	"runtime"
 	"testing"
 )
@@ -39,6 +43,36 @@ func AllTwoTeamScenarios(t *testing.T, calc skills.Calc) {
 	FourOnFourSimpleTest(t, calc)
 }
 
+func AllMultipleTeamScenarios(t *testing.T, calc skills.Calc) {
+	ThreeTeamsOfOneNotDrawn(t, calc)
+	ThreeTeamsOfOneDrawn(t, calc)
+	FourTeamsOfOneNotDrawn(t, calc)
+	FiveTeamsOfOneNotDrawn(t, calc)
+	EightTeamsOfOneDrawn(t, calc)
+	EightTeamsOfOneUpset(t, calc)
+	SixteenTeamsOfOneNotDrawn(t, calc)
+
+	TwoOnFourOnTwoWinDraw(t, calc)
+}
+
+func AllPartialPlayScenarios(t *testing.T, calc skills.Calc) {
+	OneOnOneHalfPlay(t, calc)
+	OneOnTwoBalancedPartialPlay(t, calc)
+}
+
+func AllInvalidInputScenarios(t *testing.T, calc skills.TryCalc) {
+	InvalidTeamCount(t, calc)
+	EmptyTeam(t, calc)
+	RankCountMismatch(t, calc)
+	InvalidRatings(t, calc)
+	InvalidPartialPlay(t, calc)
+	InvalidGameInfo(t, calc)
+}
+
+func AllPartialUpdateScenarios(t *testing.T, calc skills.Calc) {
+	OneOnOnePartialUpdate(t, calc)
+}
+
 //------------------- Actual Tests ---------------------------
 // If you see more than 3 digits of precision in the decimal point, then the expected values calculated from
 // F# RalfH's implementation with the same input. It didn't support teams, so team values all came from the
@@ -55,7 +89,7 @@ func AllTwoTeamScenarios(t *testing.T, calc skills.Calc) {
 
 func TwoPlayerTestNotDrawn(t *testing.T, calc skills.Calc) {
 	Convey("Using default game info", t, func() {
-		gameInfo := skills.DefaultGameInfo
+		gameInfo := skills.DefaultGameInfo()
 		Convey("Given two rookie teams of one", func() {
 			teams := []skills.Team{skills.NewTeam(), skills.NewTeam()}
 
@@ -92,7 +126,7 @@ func TwoPlayerTestNotDrawn(t *testing.T, calc skills.Calc) {
 }
 
 func TwoPlayerTestDrawn(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, gameInfo.DefaultRating())
@@ -140,7 +174,7 @@ func TwoPlayerChessTestNotDrawn(t *testing.T, calc skills.Calc) {
 }
 
 func OneOnOneMassiveUpsetDrawTest(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, gameInfo.DefaultRating())
@@ -165,7 +199,7 @@ func OneOnOneMassiveUpsetDrawTest(t *testing.T, calc skills.Calc) {
 //------------------------------------------------------------------------------
 
 func TwoOnTwoSimpleTest(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, gameInfo.DefaultRating())
@@ -195,7 +229,7 @@ func TwoOnTwoSimpleTest(t *testing.T, calc skills.Calc) {
 }
 
 func TwoOnTwoDrawTest(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, gameInfo.DefaultRating())
@@ -221,7 +255,7 @@ func TwoOnTwoDrawTest(t *testing.T, calc skills.Calc) {
 }
 
 func TwoOnTwoUnbalancedDrawTest(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, skills.NewRating(15, 8))
@@ -247,7 +281,7 @@ func TwoOnTwoUnbalancedDrawTest(t *testing.T, calc skills.Calc) {
 }
 
 func TwoOnTwoUpsetTest(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, skills.NewRating(20, 8))
@@ -273,7 +307,7 @@ func TwoOnTwoUpsetTest(t *testing.T, calc skills.Calc) {
 }
 
 func FourOnFourSimpleTest(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, gameInfo.DefaultRating())
@@ -307,7 +341,7 @@ func FourOnFourSimpleTest(t *testing.T, calc skills.Calc) {
 }
 
 func OneOnTwoSimpleTest(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, gameInfo.DefaultRating())
@@ -331,7 +365,7 @@ func OneOnTwoSimpleTest(t *testing.T, calc skills.Calc) {
 }
 
 func OneOnTwoSomewhatBalanced(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, skills.NewRating(40, 6))
@@ -355,7 +389,7 @@ func OneOnTwoSomewhatBalanced(t *testing.T, calc skills.Calc) {
 }
 
 func OneOnThreeSimpleTest(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, gameInfo.DefaultRating())
@@ -381,7 +415,7 @@ func OneOnThreeSimpleTest(t *testing.T, calc skills.Calc) {
 }
 
 func OneOnTwoDrawTest(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, gameInfo.DefaultRating())
@@ -405,7 +439,7 @@ func OneOnTwoDrawTest(t *testing.T, calc skills.Calc) {
 }
 
 func OneOnThreeDrawTest(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, gameInfo.DefaultRating())
@@ -431,7 +465,7 @@ func OneOnThreeDrawTest(t *testing.T, calc skills.Calc) {
 }
 
 func OneOnSevenSimpleTest(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, gameInfo.DefaultRating())
@@ -465,7 +499,7 @@ func OneOnSevenSimpleTest(t *testing.T, calc skills.Calc) {
 }
 
 func ThreeOnTwoTests(t *testing.T, calc skills.Calc) {
-	gameInfo := skills.DefaultGameInfo
+	gameInfo := skills.DefaultGameInfo()
 
 	team1 := skills.NewTeam()
 	team1.AddPlayer(1, skills.NewRating(28, 7))
@@ -503,6 +537,408 @@ func ThreeOnTwoTests(t *testing.T, calc skills.Calc) {
 	AssertMatchQuality(t, 0.254, calc.CalcMatchQual(gameInfo, teams))
 }
 
+//------------------------------------------------------------------------------
+// Multiple Team Tests
+//------------------------------------------------------------------------------
+
+// teamsOfOne returns one single player team per rating, with players
+// numbered from 1.
+func teamsOfOne(ratings ...skills.Rating) []skills.Team {
+	teams := make([]skills.Team, len(ratings))
+	for i, r := range ratings {
+		teams[i] = skills.NewTeam()
+		teams[i].AddPlayer(i+1, r)
+	}
+	return teams
+}
+
+func defaultRatings(gi *skills.GameInfo, n int) []skills.Rating {
+	rs := make([]skills.Rating, n)
+	for i := range rs {
+		rs[i] = gi.DefaultRating()
+	}
+	return rs
+}
+
+func TwoOnFourOnTwoWinDraw(t *testing.T, calc skills.Calc) {
+	gameInfo := skills.DefaultGameInfo()
+
+	team1 := skills.NewTeam()
+	team1.AddPlayer(1, skills.NewRating(40, 4))
+	team1.AddPlayer(2, skills.NewRating(45, 3))
+
+	team2 := skills.NewTeam()
+	team2.AddPlayer(3, skills.NewRating(20, 7))
+	team2.AddPlayer(4, skills.NewRating(19, 6))
+	team2.AddPlayer(5, skills.NewRating(30, 9))
+	team2.AddPlayer(6, skills.NewRating(10, 4))
+
+	team3 := skills.NewTeam()
+	team3.AddPlayer(7, skills.NewRating(50, 5))
+	team3.AddPlayer(8, skills.NewRating(30, 2))
+
+	teams := []skills.Team{team1, team2, team3}
+
+	newRatings := calc.CalcNewRatings(gameInfo, teams, 1, 2, 2)
+
+	AssertRating(t, 40.877, 3.840, newRatings[1])
+	AssertRating(t, 45.493, 2.934, newRatings[2])
+	AssertRating(t, 19.609, 6.396, newRatings[3])
+	AssertRating(t, 18.712, 5.625, newRatings[4])
+	AssertRating(t, 29.353, 7.673, newRatings[5])
+	AssertRating(t, 9.872, 3.891, newRatings[6])
+	AssertRating(t, 48.830, 4.590, newRatings[7])
+	AssertRating(t, 29.813, 1.976, newRatings[8])
+
+	AssertMatchQuality(t, 0.367, calc.CalcMatchQual(gameInfo, teams))
+}
+
+func ThreeTeamsOfOneNotDrawn(t *testing.T, calc skills.Calc) {
+	gameInfo := skills.DefaultGameInfo()
+	teams := teamsOfOne(defaultRatings(gameInfo, 3)...)
+
+	newRatings := calc.CalcNewRatings(gameInfo, teams, 1, 2, 3)
+
+	AssertRating(t, 31.675352419172107, 6.6559853776206905, newRatings[1])
+	AssertRating(t, 25.000000000003912, 6.2078966412243233, newRatings[2])
+	AssertRating(t, 18.324647580823971, 6.6559853776218318, newRatings[3])
+
+	AssertMatchQuality(t, 0.200, calc.CalcMatchQual(gameInfo, teams))
+}
+
+func ThreeTeamsOfOneDrawn(t *testing.T, calc skills.Calc) {
+	gameInfo := skills.DefaultGameInfo()
+	teams := teamsOfOne(defaultRatings(gameInfo, 3)...)
+
+	newRatings := calc.CalcNewRatings(gameInfo, teams, 1, 1, 1)
+
+	AssertRating(t, 25.000, 5.698, newRatings[1])
+	AssertRating(t, 25.000, 5.695, newRatings[2])
+	AssertRating(t, 25.000, 5.698, newRatings[3])
+
+	AssertMatchQuality(t, 0.200, calc.CalcMatchQual(gameInfo, teams))
+}
+
+func FourTeamsOfOneNotDrawn(t *testing.T, calc skills.Calc) {
+	gameInfo := skills.DefaultGameInfo()
+	teams := teamsOfOne(defaultRatings(gameInfo, 4)...)
+
+	newRatings := calc.CalcNewRatings(gameInfo, teams, 1, 2, 3, 4)
+
+	AssertRating(t, 33.206680965631264, 6.3481091698077057, newRatings[1])
+	AssertRating(t, 27.401454693843323, 5.7871629348447584, newRatings[2])
+	AssertRating(t, 22.598545306188374, 5.7871629348413451, newRatings[3])
+	AssertRating(t, 16.793319034361271, 6.3481091698144967, newRatings[4])
+
+	AssertMatchQuality(t, 0.089, calc.CalcMatchQual(gameInfo, teams))
+}
+
+func FiveTeamsOfOneNotDrawn(t *testing.T, calc skills.Calc) {
+	gameInfo := skills.DefaultGameInfo()
+	teams := teamsOfOne(defaultRatings(gameInfo, 5)...)
+
+	newRatings := calc.CalcNewRatings(gameInfo, teams, 1, 2, 3, 4, 5)
+
+	AssertRating(t, 34.363135705841188, 6.1361528798112692, newRatings[1])
+	AssertRating(t, 29.058448805636779, 5.5358352402833413, newRatings[2])
+	AssertRating(t, 25.000000000031758, 5.4200805474429847, newRatings[3])
+	AssertRating(t, 20.941551194426314, 5.5358352402709672, newRatings[4])
+	AssertRating(t, 15.636864294158848, 6.136152879829349, newRatings[5])
+
+	AssertMatchQuality(t, 0.040, calc.CalcMatchQual(gameInfo, teams))
+}
+
+func EightTeamsOfOneDrawn(t *testing.T, calc skills.Calc) {
+	gameInfo := skills.DefaultGameInfo()
+	teams := teamsOfOne(defaultRatings(gameInfo, 8)...)
+
+	newRatings := calc.CalcNewRatings(gameInfo, teams, 1, 1, 1, 1, 1, 1, 1, 1)
+
+	AssertRating(t, 25.000, 4.592, newRatings[1])
+	AssertRating(t, 25.000, 4.583, newRatings[2])
+	AssertRating(t, 25.000, 4.576, newRatings[3])
+	AssertRating(t, 25.000, 4.573, newRatings[4])
+	AssertRating(t, 25.000, 4.573, newRatings[5])
+	AssertRating(t, 25.000, 4.576, newRatings[6])
+	AssertRating(t, 25.000, 4.583, newRatings[7])
+	AssertRating(t, 25.000, 4.592, newRatings[8])
+
+	AssertMatchQuality(t, 0.004, calc.CalcMatchQual(gameInfo, teams))
+}
+
+func EightTeamsOfOneUpset(t *testing.T, calc skills.Calc) {
+	gameInfo := skills.DefaultGameInfo()
+	teams := teamsOfOne(
+		skills.NewRating(10, 8),
+		skills.NewRating(15, 7),
+		skills.NewRating(20, 6),
+		skills.NewRating(25, 5),
+		skills.NewRating(30, 4),
+		skills.NewRating(35, 3),
+		skills.NewRating(40, 2),
+		skills.NewRating(45, 1))
+
+	newRatings := calc.CalcNewRatings(gameInfo, teams, 1, 2, 3, 4, 5, 6, 7, 8)
+
+	AssertRating(t, 35.135, 4.506, newRatings[1])
+	AssertRating(t, 32.585, 4.037, newRatings[2])
+	AssertRating(t, 31.329, 3.756, newRatings[3])
+	AssertRating(t, 30.984, 3.453, newRatings[4])
+	AssertRating(t, 31.751, 3.064, newRatings[5])
+	AssertRating(t, 34.051, 2.541, newRatings[6])
+	AssertRating(t, 38.263, 1.849, newRatings[7])
+	AssertRating(t, 44.118, 0.983, newRatings[8])
+
+	AssertMatchQuality(t, 0.000, calc.CalcMatchQual(gameInfo, teams))
+}
+
+func SixteenTeamsOfOneNotDrawn(t *testing.T, calc skills.Calc) {
+	gameInfo := skills.DefaultGameInfo()
+	teams := teamsOfOne(defaultRatings(gameInfo, 16)...)
+
+	newRatings := calc.CalcNewRatings(gameInfo, teams, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16)
+
+	AssertRating(t, 40.53945776946920, 5.27581643889050, newRatings[1])
+	AssertRating(t, 36.80951229454210, 4.71121217610266, newRatings[2])
+	AssertRating(t, 34.34726355544460, 4.52440328139991, newRatings[3])
+	AssertRating(t, 32.33614722608720, 4.43258628279632, newRatings[4])
+	AssertRating(t, 30.55048814671730, 4.38010805034365, newRatings[5])
+	AssertRating(t, 28.89277312234790, 4.34859291776483, newRatings[6])
+	AssertRating(t, 27.30952161972210, 4.33037679041216, newRatings[7])
+	AssertRating(t, 25.76571046519540, 4.32197078088701, newRatings[8])
+	AssertRating(t, 24.23428953480470, 4.32197078088703, newRatings[9])
+	AssertRating(t, 22.69047838027800, 4.33037679041219, newRatings[10])
+	AssertRating(t, 21.10722687765220, 4.34859291776488, newRatings[11])
+	AssertRating(t, 19.44951185328290, 4.38010805034375, newRatings[12])
+	AssertRating(t, 17.66385277391300, 4.43258628279643, newRatings[13])
+	AssertRating(t, 15.65273644455550, 4.52440328139996, newRatings[14])
+	AssertRating(t, 13.19048770545810, 4.71121217610273, newRatings[15])
+	AssertRating(t, 9.46054223053080, 5.27581643889032, newRatings[16])
+}
+
+//------------------------------------------------------------------------------
+// Partial Play Tests
+//------------------------------------------------------------------------------
+
+func OneOnOneHalfPlay(t *testing.T, calc skills.Calc) {
+	gameInfo := skills.DefaultGameInfo()
+
+	p1 := skills.NewPlayer(1)
+	p2 := skills.NewPartialPlayer(2, 0.5)
+
+	team1 := skills.NewTeam()
+	team1.AddPlayer(p1, gameInfo.DefaultRating())
+
+	team2 := skills.NewTeam()
+	team2.AddPlayer(p2, gameInfo.DefaultRating())
+
+	teams := []skills.Team{team1, team2}
+
+	newRatings := calc.CalcNewRatings(gameInfo, teams, 1, 2)
+
+	AssertRating(t, 26.616, 7.395, newRatings[p1])
+	AssertRating(t, 24.192, 8.109, newRatings[p2])
+
+	AssertMatchQuality(t, 0.218, calc.CalcMatchQual(gameInfo, teams))
+}
+
+func OneOnTwoBalancedPartialPlay(t *testing.T, calc skills.Calc) {
+	gameInfo := skills.DefaultGameInfo()
+
+	p1 := skills.NewPlayer(1)
+	team1 := skills.NewTeam()
+	team1.AddPlayer(p1, gameInfo.DefaultRating())
+
+	p2 := skills.NewPartialPlayer(2, 0.0)
+	p3 := skills.NewPartialPlayer(3, 1.0)
+
+	team2 := skills.NewTeam()
+	team2.AddPlayer(p2, gameInfo.DefaultRating())
+	team2.AddPlayer(p3, gameInfo.DefaultRating())
+
+	teams := []skills.Team{team1, team2}
+
+	newRatings := calc.CalcNewRatings(gameInfo, teams, 1, 2)
+
+	// Player 2 barely played, so this is almost a one on one game
+	AssertRating(t, 29.39583201999924, 7.171475587326186, newRatings[p1])
+	AssertRating(t, 25.000, 8.334, newRatings[p2])
+	AssertRating(t, 20.60416798000076, 7.171475587326186, newRatings[p3])
+
+	AssertMatchQuality(t, 0.447, calc.CalcMatchQual(gameInfo, teams))
+}
+
+//------------------------------------------------------------------------------
+// Partial Update Tests
+//------------------------------------------------------------------------------
+
+func OneOnOnePartialUpdate(t *testing.T, calc skills.Calc) {
+	gameInfo := skills.DefaultGameInfo()
+
+	for _, c := range []struct {
+		pct          float64
+		mean, stddev float64
+	}{
+		{0.0, 25.000, 8.333},
+		{0.5, 22.474, 7.687},
+		{1.0, 20.60416798000076, 7.171475587326186},
+	} {
+		p1 := skills.NewPlayer(1)
+		p2 := skills.NewPartialUpdatePlayer(2, c.pct)
+
+		teams := []skills.Team{skills.NewTeam(), skills.NewTeam()}
+		teams[0].AddPlayer(p1, gameInfo.DefaultRating())
+		teams[1].AddPlayer(p2, gameInfo.DefaultRating())
+
+		newRatings := calc.CalcNewRatings(gameInfo, teams, 1, 2)
+
+		// Only the loser's update is damped
+		AssertRating(t, 29.39583201999924, 7.171475587326186, newRatings[p1])
+		AssertRating(t, c.mean, c.stddev, newRatings[p2])
+	}
+}
+
+//------------------------------------------------------------------------------
+// Invalid Input Tests
+//------------------------------------------------------------------------------
+
+func InvalidTeamCount(t *testing.T, calc skills.TryCalc) {
+	teams := teamsOfOne(defaultRatings(skills.DefaultGameInfo(), 1)...)
+
+	_, err := calc.TryCalcNewRatings(skills.DefaultGameInfo(), teams, 1)
+	var e *skills.TeamCountError
+	AssertErrorAs(t, err, &e)
+
+	_, err = calc.TryCalcMatchQual(skills.DefaultGameInfo(), teams)
+	AssertErrorAs(t, err, &e)
+}
+
+func EmptyTeam(t *testing.T, calc skills.TryCalc) {
+	teams := teamsOfOne(defaultRatings(skills.DefaultGameInfo(), 1)...)
+	teams = append(teams, skills.NewTeam())
+
+	_, err := calc.TryCalcNewRatings(skills.DefaultGameInfo(), teams, 1, 2)
+	var e *skills.PlayerCountError
+	if AssertErrorAs(t, err, &e) && e.Team != 1 {
+		t.Errorf("e.Team = %v, want 1\n%v", e.Team, testLoc())
+	}
+}
+
+func RankCountMismatch(t *testing.T, calc skills.TryCalc) {
+	teams := teamsOfOne(defaultRatings(skills.DefaultGameInfo(), 2)...)
+
+	_, err := calc.TryCalcNewRatings(skills.DefaultGameInfo(), teams, 1)
+	var e *skills.RankCountError
+	AssertErrorAs(t, err, &e)
+}
+
+func InvalidRatings(t *testing.T, calc skills.TryCalc) {
+	for _, r := range []skills.Rating{
+		skills.NewRating(math.NaN(), 8),
+		skills.NewRating(25, -1),
+		skills.NewRating(25, 0),
+		skills.NewRating(25, math.Inf(1)),
+	} {
+		teams := teamsOfOne(skills.DefaultGameInfo().DefaultRating(), r)
+
+		_, err := calc.TryCalcNewRatings(skills.DefaultGameInfo(), teams, 1, 2)
+		var e *skills.RatingError
+		if AssertErrorAs(t, err, &e) && e.Player != 2 {
+			t.Errorf("e.Player = %v, want 2\n%v", e.Player, testLoc())
+		}
+
+		_, err = calc.TryCalcMatchQual(skills.DefaultGameInfo(), teams)
+		AssertErrorAs(t, err, &e)
+	}
+}
+
+// Used by pointer, since a NaN weight would keep the player from ever equaling
+// itself as a map key.
+type weightedPlayer struct {
+	id     int
+	weight float64
+}
+
+func (p *weightedPlayer) PartialPlayPercentage() float64 { return p.weight }
+
+func InvalidPartialPlay(t *testing.T, calc skills.TryCalc) {
+	for _, w := range []float64{math.NaN(), -0.5, 1.5} {
+		p := &weightedPlayer{2, w}
+		teams := teamsOfOne(skills.DefaultGameInfo().DefaultRating())
+		teams = append(teams, skills.NewTeam())
+		teams[1].AddPlayer(p, skills.DefaultGameInfo().DefaultRating())
+
+		_, err := calc.TryCalcNewRatings(skills.DefaultGameInfo(), teams, 1, 2)
+		var e *skills.PartialPlayError
+		if AssertErrorAs(t, err, &e) && e.Player != p {
+			t.Errorf("e.Player = %v, want %v\n%v", e.Player, p, testLoc())
+		}
+
+		_, err = calc.TryCalcMatchQual(skills.DefaultGameInfo(), teams)
+		AssertErrorAs(t, err, &e)
+	}
+}
+
+func InvalidGameInfo(t *testing.T, calc skills.TryCalc) {
+	for _, c := range []struct {
+		field string
+		gi    skills.GameInfo
+	}{
+		{"Beta", skills.GameInfo{InitialMean: 25, InitialStddev: 8, Beta: 0}},
+		{"DrawProbability", skills.GameInfo{InitialMean: 25, InitialStddev: 8, Beta: 4, DrawProbability: 1}},
+		{"DynamicsFactor", skills.GameInfo{InitialMean: 25, InitialStddev: 8, Beta: 4, DynamicsFactor: math.NaN()}},
+		{"DynamicsPerTime", skills.GameInfo{InitialMean: 25, InitialStddev: 8, Beta: 4, DynamicsPerTime: -1}},
+	} {
+		teams := teamsOfOne(defaultRatings(skills.DefaultGameInfo(), 2)...)
+
+		_, err := calc.TryCalcNewRatings(&c.gi, teams, 1, 2)
+		var e *skills.GameInfoError
+		if AssertErrorAs(t, err, &e) && e.Field != c.field {
+			t.Errorf("e.Field = %v, want %v\n%v", e.Field, c.field, testLoc())
+		}
+	}
+
+	// No game info at all is an error rather than a panic
+	teams := teamsOfOne(defaultRatings(skills.DefaultGameInfo(), 2)...)
+	_, err := calc.TryCalcNewRatings(nil, teams, 1, 2)
+	var e *skills.GameInfoError
+	if AssertErrorAs(t, err, &e) && e.Field != "" {
+		t.Errorf("e.Field = %v, want none\n%v", e.Field, testLoc())
+	}
+	_, err = calc.TryCalcMatchQual(nil, teams)
+	AssertErrorAs(t, err, &e)
+}
+
+//------------------------------------------------------------------------------
+// Reproducibility Tests
+//------------------------------------------------------------------------------
+
+// Running the same match twice must give bit for bit identical results.
+func ReproducibleResults(t *testing.T, calc skills.Calc) {
+	gameInfo := skills.DefaultGameInfo()
+
+	newTeams := func() []skills.Team {
+		team1 := skills.NewTeam()
+		team2 := skills.NewTeam()
+		for i := 0; i < 8; i++ {
+			team1.AddPlayer(i, skills.NewRating(20+float64(i)*1.1, 3+float64(i)*0.3))
+			team2.AddPlayer(100+i, skills.NewRating(25-float64(i)*0.7, 8-float64(i)*0.4))
+		}
+		return []skills.Team{team1, team2}
+	}
+
+	want := calc.CalcNewRatings(gameInfo, newTeams(), 1, 2)
+	wantQual := calc.CalcMatchQual(gameInfo, newTeams())
+	for i := 0; i < 20; i++ {
+		if got := calc.CalcNewRatings(gameInfo, newTeams(), 1, 2); !reflect.DeepEqual(got, want) {
+			t.Errorf("CalcNewRatings = %v, want %v\n%v", got, want, testLoc())
+		}
+		if got := calc.CalcMatchQual(gameInfo, newTeams()); got != wantQual {
+			t.Errorf("CalcMatchQual = %v, want %v\n%v", got, wantQual, testLoc())
+		}
+	}
+}
+
 func testLoc() string {
 	_, file, line, ok := runtime.Caller(2)
 	if ok {
@@ -519,6 +955,36 @@ func AssertRating(t *testing.T, expectedMean, expectedStddev float64, actual ski
 		t.Errorf("actual.Stddev = %v, want %v\n%v", r, expectedStddev, testLoc())
 	}
 }
+func AssertGauss(t *testing.T, expectedMean, expectedStddev float64, actual *numerics.GaussDist) {
+	const errorTolerance = 0.000001
+	if r := actual.Mean; math.Abs(r-expectedMean) > errorTolerance {
+		t.Errorf("actual.Mean = %v, want %v\n%v", r, expectedMean, testLoc())
+	}
+	if r := actual.Stddev; math.Abs(r-expectedStddev) > errorTolerance {
+		t.Errorf("actual.Stddev = %v, want %v\n%v", r, expectedStddev, testLoc())
+	}
+}
+
+func AssertLogNorm(t *testing.T, expected float64, fl factorgraphs.FactorList) {
+	const errorTolerance = 0.000001
+	if r := fl.LogNorm(); math.Abs(r-expected) > errorTolerance {
+		t.Errorf("LogNorm() = %v, want %v\n%v", r, expected, testLoc())
+	}
+}
+
+// newTestVariable returns a variable with an uninformative prior.
+func newTestVariable(name string) *factorgraphs.Variable {
+	return factorgraphs.NewVariable(name, *numerics.NewGaussDistFromPrecisionMean(0, 0))
+}
+
+func AssertErrorAs(t *testing.T, err error, target interface{}) bool {
+	if !errors.As(err, target) {
+		t.Errorf("err = %v, want %T\n%v", err, target, testLoc())
+		return false
+	}
+	return true
+}
+
 func AssertMatchQuality(t *testing.T, expectedMatchQual, actualMatchQual float64) {
 	if r := actualMatchQual; math.Abs(r-expectedMatchQual) > errorTolerance {
 		t.Errorf("actualMatchQual = %v, want %v\n%v", r, expectedMatchQual, testLoc())
@@ -1,18 +1,33 @@
 package trueskill
 
 import (
-	"github.com/ChrisHines/GoSkills/skills/numerics"
-	"math"
+	"github.com/ChrisHines/GoSkills/skills"
 )
 
 func drawMarginFromDrawProbability(drawProbability, beta float64) float64 {
-	// Derived from TrueSkill technical report (MSR-TR-2006-80), page 6
-	//
-	// draw probability = 2 * CDF(margin/(sqrt(n1+n2)*beta)) -1
-	//
-	// implies
-	//
-	// margin = inversecdf((draw probability + 1)/2) * sqrt(n1+n2) * beta
-	// n1 and n2 are the number of players on each team
-	return numerics.GaussInvCumulativeTo((drawProbability+1)/2, 0, 1) * math.Sqrt(1+1) * beta
+	return skills.DrawMarginForPlayers(drawProbability, beta, 1+1)
+}
+
+// Returns the draw margin between team1 and team2.
+func teamsDrawMargin(gi *skills.GameInfo, team1, team2 skills.Team) float64 {
+	_, _, weightSqrSum1 := teamWeightedSums(team1)
+	_, _, weightSqrSum2 := teamWeightedSums(team2)
+	return gi.DrawMargin(weightSqrSum1 + weightSqrSum2)
+}
+
+// Returns the draw margin between every pair of teams.
+func teamsDrawMargins(gi *skills.GameInfo, teams []skills.Team) [][]float64 {
+	weightSqrSums := make([]float64, len(teams))
+	for i, t := range teams {
+		_, _, weightSqrSums[i] = teamWeightedSums(t)
+	}
+
+	margins := make([][]float64, len(teams))
+	for i := range teams {
+		margins[i] = make([]float64, len(teams))
+		for j := range teams {
+			margins[i][j] = gi.DrawMargin(weightSqrSums[i] + weightSqrSums[j])
+		}
+	}
+	return margins
 }
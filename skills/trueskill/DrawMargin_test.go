@@ -1,6 +1,7 @@
 package trueskill
 
 import (
+	"github.com/ChrisHines/GoSkills/skills"
 	"math"
 	"testing"
 )
@@ -21,3 +22,86 @@ func AssertDrawMargin(t *testing.T, drawProb, beta, expected float64) {
 		t.Errorf("draw margin = %v, want %v\n%v", r, expected, testLoc())
 	}
 }
+
+func TestTeamSizeDrawMargin(t *testing.T) {
+	gi := *skills.DefaultGameInfo()
+	legacy := drawMarginFromDrawProbability(gi.DrawProbability, gi.Beta)
+
+	fourOnFour := teamsOfSize(&gi, 4, 4)
+	if got := teamsDrawMargin(&gi, fourOnFour[0], fourOnFour[1]); got != legacy {
+		t.Errorf("legacy 4v4 margin = %v, want %v", got, legacy)
+	}
+
+	gi.TeamSizeDrawMargin = true
+	if got := teamsDrawMargin(&gi, fourOnFour[0], fourOnFour[1]); math.Abs(got-2*legacy) > 1e-9 {
+		t.Errorf("4v4 margin = %v, want %v", got, 2*legacy)
+	}
+	oneOnOne := teamsOfSize(&gi, 1, 1)
+	if got := teamsDrawMargin(&gi, oneOnOne[0], oneOnOne[1]); math.Abs(got-legacy) > 1e-9 {
+		t.Errorf("1v1 margin = %v, want %v", got, legacy)
+	}
+
+	// A half time player counts a quarter as much toward the margin
+	partial := skills.NewTeam()
+	partial.AddPlayer(skills.NewPartialPlayer(9, 0.5), gi.DefaultRating())
+	want := skills.DrawMarginForPlayers(gi.DrawProbability, gi.Beta, 1.25)
+	if got := teamsDrawMargin(&gi, oneOnOne[0], partial); math.Abs(got-want) > 1e-9 {
+		t.Errorf("partial play margin = %v, want %v", got, want)
+	}
+}
+
+// Two teams of players whose skills are known exactly draw as often as the
+// game does only once the margin accounts for the number of players.
+func TeamSizeDrawProbability(t *testing.T, calc skills.Predictor) {
+	gi := *skills.DefaultGameInfo()
+	teams := teamsOfSize(&gi, 4, 4)
+	for _, team := range teams {
+		for _, p := range team.Players() {
+			team.AddPlayer(p, skills.NewRating(25, 1e-6))
+		}
+	}
+
+	if got := calc.PredictOutcome(&gi, teams).Draw; got >= gi.DrawProbability-0.01 {
+		t.Errorf("legacy Draw = %v, want well below %v\n%v", got, gi.DrawProbability, testLoc())
+	}
+	gi.TeamSizeDrawMargin = true
+	if got := calc.PredictOutcome(&gi, teams).Draw; math.Abs(got-gi.DrawProbability) > 1e-6 {
+		t.Errorf("Draw = %v, want %v\n%v", got, gi.DrawProbability, testLoc())
+	}
+}
+
+// The option changes nothing for one on one matches, and the wider margin
+// for larger teams makes a draw between unequal teams less surprising.
+func TeamSizeDrawMarginUpdates(t *testing.T, calc skills.Calc) {
+	gi := *skills.DefaultGameInfo()
+	sized := gi
+	sized.TeamSizeDrawMargin = true
+
+	oneOnOne := teamsOfSize(&gi, 1, 1)
+	if a, b := calc.CalcNewRatings(&gi, oneOnOne, 1, 2), calc.CalcNewRatings(&sized, oneOnOne, 1, 2); math.Abs(a[0].Mean()-b[0].Mean()) > 1e-9 {
+		t.Errorf("1v1 mean = %v, want %v\n%v", b[0].Mean(), a[0].Mean(), testLoc())
+	}
+
+	teams := teamsOfSize(&gi, 4, 4)
+	teams[0].AddPlayer(0, skills.NewRating(35, 3))
+	legacy := calc.CalcNewRatings(&gi, teams, 1, 1)
+	newer := calc.CalcNewRatings(&sized, teams, 1, 1)
+	if legacy[0].Mean() >= newer[0].Mean() {
+		t.Errorf("draw mean = %v, want less than %v\n%v", legacy[0].Mean(), newer[0].Mean(), testLoc())
+	}
+}
+
+// Returns teams of default rated players with ids numbered from 0 across
+// the teams.
+func teamsOfSize(gi *skills.GameInfo, sizes ...int) []skills.Team {
+	teams := make([]skills.Team, len(sizes))
+	id := 0
+	for i, n := range sizes {
+		teams[i] = skills.NewTeam()
+		for j := 0; j < n; j++ {
+			teams[i].AddPlayer(id, gi.DefaultRating())
+			id++
+		}
+	}
+	return teams
+}
@@ -0,0 +1,67 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"testing"
+)
+
+type awayPlayer struct {
+	id      int
+	elapsed float64
+}
+
+func (p awayPlayer) ElapsedTime() float64 { return p.elapsed }
+
+// A player back from a long break is less certain, so the same result moves
+// their rating further.
+func ReturningPlayer(t *testing.T, calc skills.Calc) {
+	gi := *skills.DefaultGameInfo()
+	gi.DynamicsPerTime = 0.2
+	r := skills.NewRating(30, 2)
+
+	rate := func(p interface{}) skills.Rating {
+		teams := []skills.Team{skills.NewTeam(), skills.NewTeam()}
+		teams[0].AddPlayer(p, r)
+		teams[1].AddPlayer(2, r)
+		return calc.CalcNewRatings(&gi, teams, 1, 2)[p]
+	}
+
+	regular := rate(awayPlayer{1, 0})
+	returning := rate(awayPlayer{1, 365})
+
+	if returning.Mean() <= regular.Mean() {
+		t.Errorf("returning mean = %v, want more than %v\n%v", returning.Mean(), regular.Mean(), testLoc())
+	}
+	if returning.Stddev() <= regular.Stddev() {
+		t.Errorf("returning stddev = %v, want more than %v\n%v", returning.Stddev(), regular.Stddev(), testLoc())
+	}
+
+	// Without DynamicsPerTime it makes no difference
+	ignored := calc.CalcNewRatings(skills.DefaultGameInfo(), teamsOfOne(r, r), 1, 2)[1]
+	gi.DynamicsPerTime = 0
+	if got := rate(awayPlayer{1, 365}); got != ignored {
+		t.Errorf("rating = %v, want %v\n%v", got, ignored, testLoc())
+	}
+}
+
+// A player back from a very long break who upsets a strong, certain
+// opponent: the two player formulas must spread the difference by the same
+// drifted variances they update with, or the new variance goes negative.
+// With one player a side every calculator agrees with the full factor graph.
+func AbsentUpset(t *testing.T, calc skills.Calc) {
+	gi := skills.NewGameInfo(skills.WithDynamicsPerTime(1))
+	away := awayPlayer{1, 1000}
+
+	teams := []skills.Team{skills.NewTeam(), skills.NewTeam()}
+	teams[0].AddPlayer(away, skills.NewRating(0, 1))
+	teams[1].AddPlayer(2, skills.NewRating(30, 1))
+
+	want := (&FactorGraphCalc{}).CalcNewRatings(gi, teams, 1, 2)
+	got := calc.CalcNewRatings(gi, teams, 1, 2)
+
+	AssertRating(t, want[away].Mean(), want[away].Stddev(), got[away])
+	AssertRating(t, want[2].Mean(), want[2].Stddev(), got[2])
+	if s := got[away].Stddev(); !(s > 0 && s < gi.InitialStddev) {
+		t.Errorf("returning stddev = %v, want in (0, %v)\n%v", s, gi.InitialStddev, testLoc())
+	}
+}
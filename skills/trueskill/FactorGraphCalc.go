@@ -0,0 +1,265 @@
+package trueskill
+
+import (
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/factorgraphs"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+	"sort"
+)
+
+// Calculates TrueSkill using a full factor graph. It supports any number of
+// teams with one or more players each, ties included.
+type FactorGraphCalc struct{}
+
+// Calculates new ratings based on the prior ratings and team ranks use 1 for first place, repeat the number for a tie (e.g. 1, 2, 2).
+func (calc *FactorGraphCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatings but returns an error instead of panicking on invalid input.
+func (calc *FactorGraphCalc) TryCalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) (skills.PlayerRatings, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, factorGraphTeamRange, factorGraphPlayerRange); err != nil {
+		return nil, err
+	}
+
+	// Copy slices so we don't confuse the client code
+	steams := append([]skills.Team{}, teams...)
+	sranks := append([]int{}, ranks...)
+
+	// Make sure things are in order; a stable sort keeps tied teams in the
+	// order they were given so results are reproducible
+	rt, err := skills.TryNewRankedTeams(steams, sranks)
+	if err != nil {
+		return nil, err
+	}
+	sort.Stable(rt)
+
+	g := newTrueSkillFactorGraph(gi, steams, sranks)
+	g.RunSchedule()
+
+	newSkills := g.updatedRatings()
+	skills.ApplyPartialUpdates(teams, newSkills)
+
+	return newSkills, nil
+}
+
+// Calculates the match quality as the likelihood of all teams drawing (0% = bad, 100% = well matched).
+func (calc *FactorGraphCalc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Team) float64 {
+	r, err := calc.TryCalcMatchQual(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcMatchQual but returns an error instead of panicking on invalid input.
+func (calc *FactorGraphCalc) TryCalcMatchQual(gi *skills.GameInfo, teams []skills.Team) (float64, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, factorGraphTeamRange, factorGraphPlayerRange); err != nil {
+		return 0, err
+	}
+
+	// Fix the order of the players so the mean vector, covariance matrix
+	// and team assignment matrix all agree.
+	teamRatings := make([][]skills.Rating, len(teams))
+	teamWeights := make([][]float64, len(teams))
+	for i, t := range teams {
+		for _, p := range t.Players() {
+			r := t.PlayerRating(p)
+			teamRatings[i] = append(teamRatings[i], r)
+			teamWeights[i] = append(teamWeights[i], skills.PartialPlayPercentage(p))
+		}
+	}
+
+	meanVector := playerMeansVector(teamRatings)
+	meanVectorT := new(numerics.Matrix).Transpose(meanVector)
+
+	skillsMatrix := playerCovarianceMatrix(teamRatings)
+
+	a := playerTeamAssignmentMatrix(teamWeights)
+	aT := new(numerics.Matrix).Transpose(a)
+
+	betaSqr := numerics.Sqr(gi.Beta)
+
+	// This is equation 4.1 in the TrueSkill paper generalized to any number
+	// of teams (see the accompanying math paper for the derivation).
+	start := new(numerics.Matrix).Mul(meanVectorT, a)
+	aTa := new(numerics.Matrix).Mul(new(numerics.Matrix).Scale(betaSqr, aT), a)
+	aTSA := new(numerics.Matrix).Mul(new(numerics.Matrix).Mul(aT, skillsMatrix), a)
+	middle := new(numerics.Matrix).Add(aTa, aTSA)
+
+	// middle is a covariance matrix, so a Cholesky decomposition gives a
+	// stable solve and determinant without forming the inverse.
+	middleChol, ok := numerics.NewCholesky(middle)
+	if !ok {
+		return 0, fmt.Errorf("match quality covariance is not positive definite")
+	}
+	aTaChol, ok := numerics.NewCholesky(aTa)
+	if !ok {
+		return 0, fmt.Errorf("team assignment matrix is rank deficient")
+	}
+
+	end := new(numerics.Matrix).Mul(aT, meanVector)
+
+	expPartMatrix := new(numerics.Matrix).Mul(start, middleChol.Solve(end))
+	expPart := -0.5 * expPartMatrix.At(0, 0)
+
+	sqrtPart := math.Exp(aTaChol.LogDeterminant() - middleChol.LogDeterminant())
+
+	return math.Exp(expPart) * math.Sqrt(sqrtPart), nil
+}
+
+// A simple vector of all the player means.
+func playerMeansVector(teamRatings [][]skills.Rating) *numerics.Matrix {
+	means := []float64{}
+	for _, rs := range teamRatings {
+		for _, r := range rs {
+			means = append(means, r.Mean())
+		}
+	}
+	return numerics.NewVector(means...)
+}
+
+// A square matrix whose diagonal values are the variances of all players.
+func playerCovarianceMatrix(teamRatings [][]skills.Rating) *numerics.Matrix {
+	variances := []float64{}
+	for _, rs := range teamRatings {
+		for _, r := range rs {
+			variances = append(variances, r.Variance())
+		}
+	}
+	return numerics.NewDiagonalMatrix(variances...)
+}
+
+// The team assignment matrix is often referred to as the "A" matrix. It's a matrix whose rows represent the players
+// and the columns represent teams. At Matrix[row, column] represents that player[row] is on team[col]
+// Positive values represent an assignment and a negative value means that we subtract the value of the next
+// team since we're dealing with pairs. This means that this matrix always has teams - 1 columns.
+// The values are each player's partial play weight rather than exactly 1.
+//
+// For example, consider a 3 team game where team1 is just player1, team 2 is player 2 and player 3, and
+// team3 is just player 4. Furthermore, player 2 and player 3 on team 2 played 25% and 75% of the time
+// (e.g. partial play), the A matrix would be:
+//
+//	A = this 4x2 matrix:
+//	|  1.00  0.00 |
+//	| -0.25  0.25 |
+//	| -0.75  0.75 |
+//	|  0.00 -1.00 |
+func playerTeamAssignmentMatrix(teamWeights [][]float64) *numerics.Matrix {
+	totalPlayers := 0
+	for _, ws := range teamWeights {
+		totalPlayers += len(ws)
+	}
+
+	a := numerics.NewMatrix(totalPlayers, len(teamWeights)-1)
+
+	row := 0
+	for col := 0; col < len(teamWeights)-1; col++ {
+		for _, w := range teamWeights[col] {
+			// indicates the player is on the team
+			a.Set(row, col, w)
+			row++
+		}
+		for i, w := range teamWeights[col+1] {
+			// Add a -1 * playing time to represent the difference
+			a.Set(row+i, col, -w)
+		}
+	}
+
+	return a
+}
+
+// Predicts the result of a match between exactly two teams.
+func (calc *FactorGraphCalc) PredictOutcome(gi *skills.GameInfo, teams []skills.Team) skills.Prediction {
+	r, err := calc.TryPredictOutcome(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like PredictOutcome but returns an error instead of panicking on invalid input.
+func (calc *FactorGraphCalc) TryPredictOutcome(gi *skills.GameInfo, teams []skills.Team) (skills.Prediction, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, predictOutcomeTeamRange, factorGraphPlayerRange); err != nil {
+		return skills.Prediction{}, err
+	}
+
+	return predictOutcome(gi, teams[0], teams[1]), nil
+}
+
+// Predicts where each team will finish; element [i][k] is the probability that teams[i] finishes in position k+1.
+func (calc *FactorGraphCalc) PredictRanks(gi *skills.GameInfo, teams []skills.Team) [][]float64 {
+	r, err := calc.TryPredictRanks(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like PredictRanks but returns an error instead of panicking on invalid input.
+func (calc *FactorGraphCalc) TryPredictRanks(gi *skills.GameInfo, teams []skills.Team) ([][]float64, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, factorGraphTeamRange, factorGraphPlayerRange); err != nil {
+		return nil, err
+	}
+
+	return predictRanks(gi, teams), nil
+}
+
+var (
+	factorGraphTeamRange   = numerics.AtLeast(2)
+	factorGraphPlayerRange = numerics.AtLeast(1)
+)
+
+type trueSkillFactorGraph struct {
+	factorgraphs.FactorGraph
+	gi         *skills.GameInfo
+	varFactory *factorgraphs.VariableFactory
+	priorLayer *playerPriorValuesToSkillsLayer
+}
+
+// newTrueSkillFactorGraph builds the graph for teams, which must be sorted
+// by rank.
+func newTrueSkillFactorGraph(gi *skills.GameInfo, teams []skills.Team, ranks []int) *trueSkillFactorGraph {
+	g := &trueSkillFactorGraph{
+		gi: gi,
+		varFactory: factorgraphs.NewVariableFactory(func() numerics.GaussDist {
+			return *numerics.NewGaussDistFromPrecisionMean(0, 0)
+		}),
+	}
+
+	g.priorLayer = newPlayerPriorValuesToSkillsLayer(g, teams)
+	perfLayer := newPlayerSkillsToPerformancesLayer(g, g.priorLayer.skills)
+	teamPerfLayer := newPlayerPerformancesToTeamPerformancesLayer(g, perfLayer.perfs)
+
+	// Each team is compared with the next one, with a draw margin to suit
+	// the pair
+	margins := make([]float64, len(teams)-1)
+	for i := range margins {
+		margins[i] = teamsDrawMargin(gi, teams[i], teams[i+1])
+	}
+	innerLayer := newIteratedTeamDifferencesInnerLayer(g, teamPerfLayer.teamPerfs, ranks, margins)
+
+	g.Layers = []factorgraphs.Layer{g.priorLayer, perfLayer, teamPerfLayer, innerLayer}
+
+	return g
+}
+
+func (g *trueSkillFactorGraph) updatedRatings() skills.PlayerRatings {
+	newSkills := make(skills.PlayerRatings)
+	for _, team := range g.priorLayer.skills {
+		for _, skill := range team {
+			newSkills[skill.Key] = skills.NewRating(skill.Value.Mean, skill.Value.Stddev)
+		}
+	}
+	return newSkills
+}
@@ -0,0 +1,41 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+	"testing"
+)
+
+func TestFactorGraphCalc(t *testing.T) {
+	calc := &FactorGraphCalc{}
+
+	// We can test all classes
+	AllTwoPlayerScenarios(t, calc)
+	AllTwoTeamScenarios(t, calc)
+	AllMultipleTeamScenarios(t, calc)
+	AllPartialPlayScenarios(t, calc)
+	AllPartialUpdateScenarios(t, calc)
+	AllInvalidInputScenarios(t, calc)
+	ReturningPlayer(t, calc)
+	AbsentUpset(t, calc)
+	TeamSizeDrawMarginUpdates(t, calc)
+	TeamSizeDrawProbability(t, calc)
+	AllTwoPlayerPredictionScenarios(t, calc)
+	AllMultipleTeamPredictionScenarios(t, calc)
+	ReproducibleResults(t, calc)
+}
+
+type nanPlayer int
+
+func (nanPlayer) PartialPlayPercentage() float64 { return math.NaN() }
+
+// Match quality that can't be computed is an error, not a panic.
+func TestFactorGraphCalcMatchQualFailure(t *testing.T) {
+	teams := teamsOfOne(defaultRatings(skills.DefaultGameInfo(), 3)...)
+	teams[1] = skills.NewTeam()
+	teams[1].AddPlayer(nanPlayer(2), skills.DefaultGameInfo().DefaultRating())
+
+	if _, err := (&FactorGraphCalc{}).TryCalcMatchQual(skills.DefaultGameInfo(), teams); err == nil {
+		t.Errorf("TryCalcMatchQual succeeded with a NaN weight")
+	}
+}
@@ -0,0 +1,246 @@
+package trueskill
+
+import (
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/factorgraphs"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"strings"
+)
+
+// The layers of the TrueSkill factor graph, from top to bottom:
+//
+//	prior -> skill -> performance -> team performance -> difference -> comparison
+//
+// Each layer's constructor builds its factors from the output variables of
+// the layer above it.
+
+func stepsForFactors(name string, fs []factorgraphs.Factor, index int) []factorgraphs.Schedule {
+	steps := make([]factorgraphs.Schedule, len(fs))
+	for i, f := range fs {
+		steps[i] = factorgraphs.NewScheduleStep(name, f, index)
+	}
+	return steps
+}
+
+// Supplies each player's skill variable with the player's prior rating.
+// We intentionally have no posterior schedule.
+type playerPriorValuesToSkillsLayer struct {
+	factorgraphs.LayerBase
+	skills [][]*factorgraphs.KeyedVariable
+}
+
+func newPlayerPriorValuesToSkillsLayer(g *trueSkillFactorGraph, teams []skills.Team) *playerPriorValuesToSkillsLayer {
+	l := &playerPriorValuesToSkillsLayer{}
+
+	for _, t := range teams {
+		teamSkills := []*factorgraphs.KeyedVariable{}
+		for _, p := range t.Players() {
+			r := t.PlayerRating(p)
+			skill := g.varFactory.CreateKeyedVariable(p, "%v's skill", p)
+			l.AddFactor(NewGaussianPriorFactor(r.Mean(), g.gi.DynamicVariance(p, r), &skill.Variable))
+			teamSkills = append(teamSkills, skill)
+		}
+		l.skills = append(l.skills, teamSkills)
+	}
+
+	return l
+}
+
+func (l *playerPriorValuesToSkillsLayer) PriorSchedule() factorgraphs.Schedule {
+	return factorgraphs.NewScheduleSequence("All priors", stepsForFactors("Prior to Skill Step", l.LocalFactors, 0)...)
+}
+
+// Connects each player's skill to a noisy performance.
+type playerSkillsToPerformancesLayer struct {
+	factorgraphs.LayerBase
+	perfs [][]*factorgraphs.KeyedVariable
+}
+
+func newPlayerSkillsToPerformancesLayer(g *trueSkillFactorGraph, teamSkills [][]*factorgraphs.KeyedVariable) *playerSkillsToPerformancesLayer {
+	l := &playerSkillsToPerformancesLayer{}
+	betaSqr := numerics.Sqr(g.gi.Beta)
+
+	for _, ts := range teamSkills {
+		teamPerfs := []*factorgraphs.KeyedVariable{}
+		for _, skill := range ts {
+			perf := g.varFactory.CreateKeyedVariable(skill.Key, "%v's performance", skill.Key)
+			l.AddFactor(NewGaussianLikelihoodFactor(betaSqr, &perf.Variable, &skill.Variable))
+			teamPerfs = append(teamPerfs, perf)
+		}
+		l.perfs = append(l.perfs, teamPerfs)
+	}
+
+	return l
+}
+
+func (l *playerSkillsToPerformancesLayer) PriorSchedule() factorgraphs.Schedule {
+	return factorgraphs.NewScheduleSequence("All skill to performance sending", stepsForFactors("Skill to Perf step", l.LocalFactors, 0)...)
+}
+
+func (l *playerSkillsToPerformancesLayer) PosteriorSchedule() factorgraphs.Schedule {
+	return factorgraphs.NewScheduleSequence("All skill to performance sending", stepsForFactors("Perf to Skill step", l.LocalFactors, 1)...)
+}
+
+// Sums the player performances of each team into a team performance.
+type playerPerformancesToTeamPerformancesLayer struct {
+	factorgraphs.LayerBase
+	teamPerfs []*factorgraphs.Variable
+}
+
+func newPlayerPerformancesToTeamPerformancesLayer(g *trueSkillFactorGraph, teamPlayerPerfs [][]*factorgraphs.KeyedVariable) *playerPerformancesToTeamPerformancesLayer {
+	l := &playerPerformancesToTeamPerformancesLayer{}
+
+	for _, tp := range teamPlayerPerfs {
+		names := make([]string, len(tp))
+		vars := make([]*factorgraphs.Variable, len(tp))
+		weights := make([]float64, len(tp))
+		for i, perf := range tp {
+			names[i] = fmt.Sprint(perf.Key)
+			vars[i] = &perf.Variable
+			weights[i] = skills.PartialPlayPercentage(perf.Key)
+		}
+
+		teamPerf := g.varFactory.CreateBasicVariable("Team[%v]'s performance", strings.Join(names, ", "))
+		l.AddFactor(NewGaussianWeightedSumFactor(teamPerf, vars, weights))
+		l.teamPerfs = append(l.teamPerfs, teamPerf)
+	}
+
+	return l
+}
+
+func (l *playerPerformancesToTeamPerformancesLayer) PriorSchedule() factorgraphs.Schedule {
+	return factorgraphs.NewScheduleSequence("all player perf to team perf schedule", stepsForFactors("Perf to Team Perf Step", l.LocalFactors, 0)...)
+}
+
+func (l *playerPerformancesToTeamPerformancesLayer) PosteriorSchedule() factorgraphs.Schedule {
+	steps := []factorgraphs.Schedule{}
+	for _, f := range l.LocalFactors {
+		for i := 1; i < f.NumMessages(); i++ {
+			steps = append(steps, factorgraphs.NewScheduleStep(fmt.Sprintf("team sum perf @%v", i), f, i))
+		}
+	}
+	return factorgraphs.NewScheduleSequence("all of the team's sum iterations", steps...)
+}
+
+// Computes the performance difference between each pair of adjacent teams.
+// Its schedule is driven by iteratedTeamDifferencesInnerLayer.
+type teamPerformancesToTeamPerformanceDifferencesLayer struct {
+	factorgraphs.LayerBase
+	diffs []*factorgraphs.Variable
+}
+
+func newTeamPerformancesToTeamPerformanceDifferencesLayer(g *trueSkillFactorGraph, teamPerfs []*factorgraphs.Variable) *teamPerformancesToTeamPerformanceDifferencesLayer {
+	l := &teamPerformancesToTeamPerformanceDifferencesLayer{}
+
+	for i := 0; i < len(teamPerfs)-1; i++ {
+		stronger, weaker := teamPerfs[i], teamPerfs[i+1]
+		diff := g.varFactory.CreateBasicVariable("Team performance difference")
+		l.AddFactor(NewGaussianWeightedSumFactor(diff, []*factorgraphs.Variable{stronger, weaker}, []float64{1, -1}))
+		l.diffs = append(l.diffs, diff)
+	}
+
+	return l
+}
+
+// Compares each team performance difference with the draw margin.
+// Its schedule is driven by iteratedTeamDifferencesInnerLayer.
+type teamDifferencesComparisonLayer struct {
+	factorgraphs.LayerBase
+}
+
+func newTeamDifferencesComparisonLayer(g *trueSkillFactorGraph, diffs []*factorgraphs.Variable, ranks []int, margins []float64) *teamDifferencesComparisonLayer {
+	l := &teamDifferencesComparisonLayer{}
+
+	for i, diff := range diffs {
+		if ranks[i] == ranks[i+1] {
+			l.AddFactor(NewGaussianWithinFactor(margins[i], diff))
+		} else {
+			l.AddFactor(NewGaussianGreaterThanFactor(margins[i], diff))
+		}
+	}
+
+	return l
+}
+
+// The whole purpose of this is to do a loop on the bottom.
+type iteratedTeamDifferencesInnerLayer struct {
+	diffLayer *teamPerformancesToTeamPerformanceDifferencesLayer
+	compLayer *teamDifferencesComparisonLayer
+}
+
+func newIteratedTeamDifferencesInnerLayer(g *trueSkillFactorGraph, teamPerfs []*factorgraphs.Variable, ranks []int, margins []float64) *iteratedTeamDifferencesInnerLayer {
+	diffLayer := newTeamPerformancesToTeamPerformanceDifferencesLayer(g, teamPerfs)
+	compLayer := newTeamDifferencesComparisonLayer(g, diffLayer.diffs, ranks, margins)
+	return &iteratedTeamDifferencesInnerLayer{diffLayer, compLayer}
+}
+
+func (l *iteratedTeamDifferencesInnerLayer) Factors() []factorgraphs.Factor {
+	fs := append([]factorgraphs.Factor{}, l.diffLayer.Factors()...)
+	return append(fs, l.compLayer.Factors()...)
+}
+
+func (l *iteratedTeamDifferencesInnerLayer) PriorSchedule() factorgraphs.Schedule {
+	diffFactors := l.diffLayer.LocalFactors
+
+	var loop factorgraphs.Schedule
+	if len(diffFactors) == 1 {
+		loop = l.twoTeamInnerPriorLoopSchedule()
+	} else {
+		loop = l.multipleTeamInnerPriorLoopSchedule()
+	}
+
+	// When dealing with differences, there are always (n-1) differences
+	totalTeamDiffs := len(diffFactors)
+
+	return factorgraphs.NewScheduleSequence("inner schedule",
+		loop,
+		factorgraphs.NewScheduleStep("teamPerformanceToPerformanceDifferenceFactors[0] @ 1", diffFactors[0], 1),
+		factorgraphs.NewScheduleStep(
+			fmt.Sprintf("teamPerformanceToPerformanceDifferenceFactors[teamTeamDifferences = %v - 1] @ 2", totalTeamDiffs),
+			diffFactors[totalTeamDiffs-1], 2))
+}
+
+func (l *iteratedTeamDifferencesInnerLayer) PosteriorSchedule() factorgraphs.Schedule {
+	return nil
+}
+
+func (l *iteratedTeamDifferencesInnerLayer) twoTeamInnerPriorLoopSchedule() factorgraphs.Schedule {
+	return factorgraphs.NewScheduleSequence("loop of just two teams inner sequence",
+		factorgraphs.NewScheduleStep("send team perf to perf differences", l.diffLayer.LocalFactors[0], 0),
+		factorgraphs.NewScheduleStep("send to greater than or within factor", l.compLayer.LocalFactors[0], 0))
+}
+
+func (l *iteratedTeamDifferencesInnerLayer) multipleTeamInnerPriorLoopSchedule() factorgraphs.Schedule {
+	diffFactors := l.diffLayer.LocalFactors
+	compFactors := l.compLayer.LocalFactors
+	totalTeamDiffs := len(diffFactors)
+
+	forward := []factorgraphs.Schedule{}
+	for i := 0; i < totalTeamDiffs-1; i++ {
+		forward = append(forward, factorgraphs.NewScheduleSequence(
+			fmt.Sprintf("current forward schedule piece %v", i),
+			factorgraphs.NewScheduleStep(fmt.Sprintf("team perf to perf diff %v", i), diffFactors[i], 0),
+			factorgraphs.NewScheduleStep(fmt.Sprintf("greater than or within result factor %v", i), compFactors[i], 0),
+			factorgraphs.NewScheduleStep(fmt.Sprintf("team perf to perf diff factors [%v], 2", i), diffFactors[i], 2)))
+	}
+
+	backward := []factorgraphs.Schedule{}
+	for i := 0; i < totalTeamDiffs-1; i++ {
+		j := totalTeamDiffs - 1 - i
+		backward = append(backward, factorgraphs.NewScheduleSequence(
+			"current backward schedule piece",
+			factorgraphs.NewScheduleStep(fmt.Sprintf("teamPerformanceToPerformanceDifferenceFactors[totalTeamDifferences - 1 - %v] @ 0", i), diffFactors[j], 0),
+			factorgraphs.NewScheduleStep(fmt.Sprintf("greaterThanOrWithinResultFactors[totalTeamDifferences - 1 - %v] @ 0", i), compFactors[j], 0),
+			factorgraphs.NewScheduleStep(fmt.Sprintf("teamPerformanceToPerformanceDifferenceFactors[totalTeamDifferences - 1 - %v] @ 1", i), diffFactors[j], 1)))
+	}
+
+	const initialMaxDelta = 0.0001
+
+	return factorgraphs.NewScheduleLoop(
+		fmt.Sprintf("loop with max delta of %v", initialMaxDelta),
+		factorgraphs.NewScheduleSequence("forward Backward Schedule To Loop",
+			factorgraphs.NewScheduleSequence("forward schedule", forward...),
+			factorgraphs.NewScheduleSequence("backward schedule", backward...)),
+		initialMaxDelta)
+}
@@ -0,0 +1,128 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"math/rand"
+	"sort"
+)
+
+const (
+	DefaultExactTeams = 16
+	DefaultSamples    = 10000
+)
+
+// Predicts each team's chance of finishing in each position of a free-for-all
+// before it is played. Small lobbies are integrated exactly, as by the
+// calculators' PredictRanks; the cost of that grows with the cube of the
+// number of teams, so larger lobbies are simulated instead.
+type FinishingPositions struct {
+	// The largest number of teams integrated exactly; 0 means
+	// DefaultExactTeams.
+	ExactTeams int
+
+	// The number of matches simulated for larger lobbies; 0 or less means
+	// DefaultSamples. Quadrupling the samples halves the error in each
+	// probability.
+	Samples int
+
+	// The source of randomness for simulations; nil means a source with a
+	// fixed seed, so the same teams always give the same prediction.
+	Rand *rand.Rand
+}
+
+// Returns the probability of each team finishing in each position; element
+// [i][k] is the probability that teams[i] finishes in position k+1, where a
+// team's position is one more than the number of teams that beat it.
+func (fp *FinishingPositions) Predict(gi *skills.GameInfo, teams []skills.Team) [][]float64 {
+	r, err := fp.TryPredict(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like Predict but returns an error instead of panicking on invalid input.
+func (fp *FinishingPositions) TryPredict(gi *skills.GameInfo, teams []skills.Team) ([][]float64, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, factorGraphTeamRange, factorGraphPlayerRange); err != nil {
+		return nil, err
+	}
+
+	if len(teams) <= fp.exactTeams() {
+		return predictRanks(gi, teams), nil
+	}
+	return fp.simulate(gi, teams), nil
+}
+
+func (fp *FinishingPositions) simulate(gi *skills.GameInfo, teams []skills.Team) [][]float64 {
+	margins := teamsDrawMargins(gi, teams)
+	rng := fp.Rand
+	if rng == nil {
+		rng = rand.New(rand.NewSource(1))
+	}
+	samples := fp.samples()
+
+	ranks := make([][]float64, len(teams))
+	means := make([]float64, len(teams))
+	stddevs := make([]float64, len(teams))
+	for i, t := range teams {
+		ranks[i] = make([]float64, len(teams))
+		perf := teamPerformance(gi, t)
+		means[i], stddevs[i] = perf.Mean, perf.Stddev
+	}
+
+	perfs := make([]float64, len(teams))
+	sorted := make([]float64, len(teams))
+	for s := 0; s < samples; s++ {
+		for i := range perfs {
+			perfs[i] = means[i] + stddevs[i]*rng.NormFloat64()
+		}
+
+		// A team is beaten by every team that outperforms it by more than
+		// the draw margin
+		if !gi.TeamSizeDrawMargin {
+			// Every pair shares a margin, so a sort finds them quickly
+			drawMargin := margins[0][0]
+			copy(sorted, perfs)
+			sort.Float64s(sorted)
+			for i, x := range perfs {
+				notBeaten := sort.SearchFloat64s(sorted, x+drawMargin)
+				for notBeaten < len(sorted) && sorted[notBeaten] == x+drawMargin {
+					notBeaten++
+				}
+				ranks[i][len(sorted)-notBeaten]++
+			}
+			continue
+		}
+		for i, x := range perfs {
+			beaten := 0
+			for j, y := range perfs {
+				if j != i && y-x > margins[i][j] {
+					beaten++
+				}
+			}
+			ranks[i][beaten]++
+		}
+	}
+
+	for _, row := range ranks {
+		for k := range row {
+			row[k] /= float64(samples)
+		}
+	}
+	return ranks
+}
+
+func (fp *FinishingPositions) exactTeams() int {
+	if fp.ExactTeams == 0 {
+		return DefaultExactTeams
+	}
+	return fp.ExactTeams
+}
+
+func (fp *FinishingPositions) samples() int {
+	if fp.Samples <= 0 {
+		return DefaultSamples
+	}
+	return fp.Samples
+}
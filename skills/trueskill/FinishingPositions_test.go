@@ -0,0 +1,101 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func lobby(gi *skills.GameInfo, n int) []skills.Team {
+	ratings := make([]skills.Rating, n)
+	for i := range ratings {
+		ratings[i] = skills.NewRating(gi.InitialMean+float64(n/2-i), gi.InitialStddev/2)
+	}
+	return teamsOfOne(ratings...)
+}
+
+func TestFinishingPositionsExact(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+	teams := lobby(gi, 5)
+
+	got := (&FinishingPositions{}).Predict(gi, teams)
+	if want := (&FactorGraphCalc{}).PredictRanks(gi, teams); !reflect.DeepEqual(got, want) {
+		t.Errorf("Predict = %v, want %v", got, want)
+	}
+}
+
+func TestFinishingPositionsSimulated(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+	teams := lobby(gi, 5)
+
+	exact := (&FinishingPositions{}).Predict(gi, teams)
+	fp := &FinishingPositions{ExactTeams: 1, Samples: 40000, Rand: rand.New(rand.NewSource(7))}
+	simulated := fp.Predict(gi, teams)
+
+	for i := range exact {
+		for k := range exact[i] {
+			if d := math.Abs(simulated[i][k] - exact[i][k]); d > 0.01 {
+				t.Errorf("simulated[%v][%v] = %v, want %v", i, k, simulated[i][k], exact[i][k])
+			}
+		}
+	}
+
+	// The default source is seeded, so results are reproducible
+	fp = &FinishingPositions{ExactTeams: 1, Samples: 100}
+	if a, b := fp.Predict(gi, teams), fp.Predict(gi, teams); !reflect.DeepEqual(a, b) {
+		t.Errorf("Predict = %v then %v, want the same", a, b)
+	}
+}
+
+func TestFinishingPositionsLargeLobby(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+	teams := lobby(gi, 40)
+
+	ranks := (&FinishingPositions{Samples: 2000}).Predict(gi, teams)
+	if len(ranks) != 40 {
+		t.Fatalf("len(ranks) = %v, want 40", len(ranks))
+	}
+	for i, row := range ranks {
+		sum := 0.0
+		for _, p := range row {
+			sum += p
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("ranks[%v] sums to %v, want 1", i, sum)
+		}
+	}
+	if ranks[0][0] <= ranks[39][0] {
+		t.Errorf("the strongest team wins with %v, want more than the weakest's %v", ranks[0][0], ranks[39][0])
+	}
+}
+
+func TestFinishingPositionsInvalidInput(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+
+	_, err := (&FinishingPositions{}).TryPredict(gi, lobby(gi, 1))
+	var teamErr *skills.TeamCountError
+	AssertErrorAs(t, err, &teamErr)
+
+	_, err = (&FinishingPositions{}).TryPredict(gi, append(lobby(gi, 2), skills.NewTeam()))
+	var playerErr *skills.PlayerCountError
+	AssertErrorAs(t, err, &playerErr)
+}
+
+func TestFinishingPositionsTeamSizeDrawMargin(t *testing.T) {
+	gi := *skills.DefaultGameInfo()
+	gi.TeamSizeDrawMargin = true
+	teams := teamsOfSize(&gi, 1, 2, 3)
+
+	exact := (&FinishingPositions{}).Predict(&gi, teams)
+	simulated := (&FinishingPositions{ExactTeams: 1, Samples: 40000}).Predict(&gi, teams)
+
+	for i := range exact {
+		for k := range exact[i] {
+			if d := math.Abs(simulated[i][k] - exact[i][k]); d > 0.01 {
+				t.Errorf("simulated[%v][%v] = %v, want %v", i, k, simulated[i][k], exact[i][k])
+			}
+		}
+	}
+}
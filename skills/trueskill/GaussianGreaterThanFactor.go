@@ -0,0 +1,61 @@
+package trueskill
+
+import (
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills/factorgraphs"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+)
+
+// Factor representing a team difference that has exceeded the draw margin.
+// See the accompanying math paper for more details.
+type GaussianGreaterThanFactor struct {
+	factorgraphs.FactorBase
+	epsilon float64
+}
+
+func NewGaussianGreaterThanFactor(epsilon float64, v *factorgraphs.Variable) *GaussianGreaterThanFactor {
+	f := &GaussianGreaterThanFactor{
+		FactorBase: factorgraphs.NewFactorBase(fmt.Sprintf("%v > %.3f", v, epsilon)),
+		epsilon:    epsilon,
+	}
+	f.BindVariable(v)
+	return f
+}
+
+func (f *GaussianGreaterThanFactor) LogNorm() float64 {
+	marginal := &f.Variables[0].Value
+	message := &f.Messages[0].Value
+	messageFromVariable := new(numerics.GaussDist).Div(marginal, message)
+	return -numerics.LogProdNorm(messageFromVariable, message) +
+		math.Log(numerics.GaussCumulativeTo((messageFromVariable.Mean-f.epsilon)/messageFromVariable.Stddev))
+}
+
+func (f *GaussianGreaterThanFactor) UpdateMessage(i int) float64 {
+	m, v := f.Messages[i], f.Variables[i]
+	oldMarginal, oldMessage := v.Value, m.Value
+	messageFromVariable := new(numerics.GaussDist).Div(&oldMarginal, &oldMessage)
+
+	c := messageFromVariable.Precision
+	d := messageFromVariable.PrecisionMean
+
+	sqrtC := math.Sqrt(c)
+	dOnSqrtC := d / sqrtC
+	epsilonTimesSqrtC := f.epsilon * sqrtC
+
+	denom := 1 - numerics.WExceedsMargin(dOnSqrtC, epsilonTimesSqrtC)
+
+	newPrecision := c / denom
+	newPrecisionMean := (d + sqrtC*numerics.VExceedsMargin(dOnSqrtC, epsilonTimesSqrtC)) / denom
+
+	newMarginal := numerics.NewGaussDistFromPrecisionMean(newPrecisionMean, newPrecision)
+	newMessage := new(numerics.GaussDist).Mul(&oldMessage, newMarginal)
+	newMessage.Div(newMessage, &oldMarginal)
+
+	// Update the message and marginal
+	m.Value = *newMessage
+	v.Value = *newMarginal
+
+	// Return the difference in the new marginal
+	return numerics.AbsDiff(newMarginal, &oldMarginal)
+}
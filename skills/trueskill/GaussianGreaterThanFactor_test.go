@@ -0,0 +1,38 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills/factorgraphs"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+	"testing"
+)
+
+func TestGaussianGreaterThanFactor(t *testing.T) {
+	diff := newTestVariable("diff")
+	prior := NewGaussianPriorFactor(0, 1, diff)
+	f := NewGaussianGreaterThanFactor(0, diff)
+
+	prior.UpdateMessage(0)
+	f.UpdateMessage(0)
+
+	// The moments of a standard normal truncated to x > 0
+	mean := math.Sqrt(2 / math.Pi)
+	AssertGauss(t, mean, math.Sqrt(1-mean*mean), &diff.Value)
+
+	// The evidence is the probability that x > 0
+	AssertLogNorm(t, math.Log(0.5), factorgraphs.FactorList{prior, f})
+}
+
+func TestGaussianGreaterThanFactorLogNorm(t *testing.T) {
+	const mean, stddev, epsilon = 1.5, 2.0, 0.75
+
+	diff := newTestVariable("diff")
+	prior := NewGaussianPriorFactor(mean, stddev*stddev, diff)
+	f := NewGaussianGreaterThanFactor(epsilon, diff)
+
+	prior.UpdateMessage(0)
+	f.UpdateMessage(0)
+
+	expected := math.Log(numerics.GaussCumulativeTo((mean - epsilon) / stddev))
+	AssertLogNorm(t, expected, factorgraphs.FactorList{prior, f})
+}
@@ -0,0 +1,59 @@
+package trueskill
+
+import (
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills/factorgraphs"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+)
+
+// Connects two variables and adds uncertainty.
+// See the accompanying math paper for more details.
+type GaussianLikelihoodFactor struct {
+	factorgraphs.FactorBase
+	precision float64
+}
+
+func NewGaussianLikelihoodFactor(betaSqr float64, v1, v2 *factorgraphs.Variable) *GaussianLikelihoodFactor {
+	f := &GaussianLikelihoodFactor{
+		FactorBase: factorgraphs.NewFactorBase(fmt.Sprintf("Likelihood of %v going to %v", v2, v1)),
+		precision:  1 / betaSqr,
+	}
+	f.BindVariable(v1)
+	f.BindVariable(v2)
+	return f
+}
+
+func (f *GaussianLikelihoodFactor) LogNorm() float64 {
+	return numerics.LogRatioNorm(&f.Variables[0].Value, &f.Messages[0].Value)
+}
+
+func (f *GaussianLikelihoodFactor) UpdateMessage(i int) float64 {
+	switch i {
+	case 0:
+		return f.updateHelper(f.Messages[0], f.Messages[1], f.Variables[0], f.Variables[1])
+	case 1:
+		return f.updateHelper(f.Messages[1], f.Messages[0], f.Variables[1], f.Variables[0])
+	}
+	panic(fmt.Errorf("message index [%v] out of range [0, 1]", i))
+}
+
+func (f *GaussianLikelihoodFactor) updateHelper(m1, m2 *factorgraphs.Message, v1, v2 *factorgraphs.Variable) float64 {
+	msg1, msg2 := m1.Value, m2.Value
+	marginal1, marginal2 := v1.Value, v2.Value
+
+	a := f.precision / (f.precision + marginal2.Precision - msg2.Precision)
+
+	newMessage := numerics.NewGaussDistFromPrecisionMean(
+		a*(marginal2.PrecisionMean-msg2.PrecisionMean),
+		a*(marginal2.Precision-msg2.Precision))
+
+	oldMarginalWithoutMessage := new(numerics.GaussDist).Div(&marginal1, &msg1)
+	newMarginal := new(numerics.GaussDist).Mul(oldMarginalWithoutMessage, newMessage)
+
+	// Update the message and marginal
+	m1.Value = *newMessage
+	v1.Value = *newMarginal
+
+	// Return the difference in the new marginal
+	return numerics.AbsDiff(newMarginal, &marginal1)
+}
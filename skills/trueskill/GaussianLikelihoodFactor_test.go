@@ -0,0 +1,43 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills/factorgraphs"
+	"math"
+	"testing"
+)
+
+func TestGaussianLikelihoodFactor(t *testing.T) {
+	const beta = 25.0 / 6.0
+
+	skill := newTestVariable("skill")
+	perf := newTestVariable("perf")
+
+	prior := NewGaussianPriorFactor(25, 64, skill)
+	f := NewGaussianLikelihoodFactor(beta*beta, perf, skill)
+
+	prior.UpdateMessage(0)
+
+	// Down: the performance is the skill plus noise with variance beta^2.
+	f.UpdateMessage(0)
+	AssertGauss(t, 25, math.Sqrt(64+beta*beta), &perf.Value)
+
+	// Up: with no other evidence on the performance, the skill is unchanged.
+	f.UpdateMessage(1)
+	AssertGauss(t, 25, 8, &skill.Value)
+
+	// A chain of Gaussians is still normalized.
+	AssertLogNorm(t, 0, factorgraphs.FactorList{prior, f})
+}
+
+func TestGaussianLikelihoodFactorUp(t *testing.T) {
+	skill := newTestVariable("skill")
+	perf := newTestVariable("perf")
+
+	f := NewGaussianLikelihoodFactor(4, perf, skill)
+	obs := NewGaussianPriorFactor(30, 5, perf)
+
+	obs.UpdateMessage(0)
+	f.UpdateMessage(1)
+
+	AssertGauss(t, 30, 3, &skill.Value)
+}
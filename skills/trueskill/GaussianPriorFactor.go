@@ -0,0 +1,36 @@
+package trueskill
+
+import (
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills/factorgraphs"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+)
+
+// Supplies the factor graph with prior information.
+// See the accompanying math paper for more details.
+type GaussianPriorFactor struct {
+	factorgraphs.FactorBase
+	newMessage numerics.GaussDist
+}
+
+func NewGaussianPriorFactor(mean, variance float64, v *factorgraphs.Variable) *GaussianPriorFactor {
+	f := &GaussianPriorFactor{
+		FactorBase: factorgraphs.NewFactorBase(fmt.Sprintf("Prior value going to %v", v)),
+		newMessage: *numerics.NewGaussDist(mean, math.Sqrt(variance)),
+	}
+	f.BindVariable(v)
+	return f
+}
+
+func (f *GaussianPriorFactor) UpdateMessage(i int) float64 {
+	m, v := f.Messages[i], f.Variables[i]
+	oldMarginal := v.Value
+
+	v.Value = *numerics.NewGaussDistFromPrecisionMean(
+		oldMarginal.PrecisionMean+f.newMessage.PrecisionMean-m.Value.PrecisionMean,
+		oldMarginal.Precision+f.newMessage.Precision-m.Value.Precision)
+	m.Value = f.newMessage
+
+	return numerics.AbsDiff(&oldMarginal, &v.Value)
+}
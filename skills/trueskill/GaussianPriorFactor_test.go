@@ -0,0 +1,26 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills/factorgraphs"
+	"testing"
+)
+
+func TestGaussianPriorFactor(t *testing.T) {
+	v := newTestVariable("skill")
+	f := NewGaussianPriorFactor(25, 9, v)
+
+	if delta := f.UpdateMessage(0); delta == 0 {
+		t.Errorf("first update delta = 0, want > 0")
+	}
+	AssertGauss(t, 25, 3, &v.Value)
+	AssertGauss(t, 25, 3, &f.Messages[0].Value)
+
+	// Sending the same prior again must not move the marginal.
+	if delta := f.UpdateMessage(0); delta > 0.000001 {
+		t.Errorf("second update delta = %v, want 0", delta)
+	}
+	AssertGauss(t, 25, 3, &v.Value)
+
+	// A prior on its own is normalized.
+	AssertLogNorm(t, 0, factorgraphs.FactorList{f})
+}
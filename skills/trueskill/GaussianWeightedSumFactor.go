@@ -0,0 +1,199 @@
+package trueskill
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills/factorgraphs"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+)
+
+// Factor that sums together multiple Gaussians.
+// See the accompanying math paper for more details.
+type GaussianWeightedSumFactor struct {
+	factorgraphs.FactorBase
+
+	// The first entry is [0, 1, 2, ...] corresponding to
+	// v[0] = a1*v[1] + a2*v[2] + ...; the rest are the orders used when
+	// solving for each of the other variables.
+	varIndexOrders [][]int
+	weights        [][]float64
+	weightsSqr     [][]float64
+}
+
+// NewGaussianSumFactor returns a factor constraining
+// sum = vars[0] + vars[1] + ...
+func NewGaussianSumFactor(sum *factorgraphs.Variable, vars []*factorgraphs.Variable) *GaussianWeightedSumFactor {
+	weights := make([]float64, len(vars))
+	for i := range weights {
+		// By default, set the weight to 1.0
+		weights[i] = 1
+	}
+	return NewGaussianWeightedSumFactor(sum, vars, weights)
+}
+
+// NewGaussianWeightedSumFactor returns a factor constraining
+// sum = weights[0]*vars[0] + weights[1]*vars[1] + ...
+func NewGaussianWeightedSumFactor(sum *factorgraphs.Variable, vars []*factorgraphs.Variable, weights []float64) *GaussianWeightedSumFactor {
+	if len(vars) != len(weights) {
+		panic(fmt.Errorf("Number of variables [%v] does not match number of weights [%v]", len(vars), len(weights)))
+	}
+
+	f := &GaussianWeightedSumFactor{
+		FactorBase:     factorgraphs.NewFactorBase(weightedSumName(sum, vars, weights)),
+		varIndexOrders: make([][]int, len(weights)+1),
+		weights:        make([][]float64, len(weights)+1),
+		weightsSqr:     make([][]float64, len(weights)+1),
+	}
+
+	// The first weights are a straightforward copy
+	// v_0 = a_1*v_1 + a_2*v_2 + ... + a_n * v_n
+	f.weights[0] = append([]float64{}, weights...)
+	f.weightsSqr[0] = make([]float64, len(weights))
+	for i, w := range weights {
+		f.weightsSqr[0][i] = w * w
+	}
+
+	f.varIndexOrders[0] = make([]int, len(vars)+1)
+	for i := range f.varIndexOrders[0] {
+		f.varIndexOrders[0][i] = i
+	}
+
+	// The rest move the variables around and divide out the constant.
+	// For example:
+	// v_1 = (-a_2 / a_1) * v_2 + (-a3/a1) * v_3 + ... + (1.0 / a_1) * v_0
+	// By convention, we'll put the v_0 term at the end
+	for wi := 1; wi < len(f.weights); wi++ {
+		curWeights := make([]float64, len(weights))
+		curWeightsSqr := make([]float64, len(weights))
+		varIndices := make([]int, len(weights)+1)
+		varIndices[0] = wi
+
+		pivot := weights[wi-1]
+
+		// keep a single variable to keep track of where we are in the slice.
+		// This is helpful since we skip over one of the spots
+		dst := 0
+
+		for src, w := range weights {
+			if src == wi-1 {
+				continue
+			}
+
+			cw := -w / pivot
+			if pivot == 0 {
+				// Getting around division by zero
+				cw = 0
+			}
+
+			curWeights[dst] = cw
+			curWeightsSqr[dst] = cw * cw
+			varIndices[dst+1] = src + 1
+			dst++
+		}
+
+		// And the final one
+		fw := 1 / pivot
+		if pivot == 0 {
+			// Getting around division by zero
+			fw = 0
+		}
+		curWeights[dst] = fw
+		curWeightsSqr[dst] = fw * fw
+		varIndices[len(varIndices)-1] = 0
+
+		f.weights[wi] = curWeights
+		f.weightsSqr[wi] = curWeightsSqr
+		f.varIndexOrders[wi] = varIndices
+	}
+
+	f.BindVariable(sum)
+	for _, v := range vars {
+		f.BindVariable(v)
+	}
+
+	return f
+}
+
+func (f *GaussianWeightedSumFactor) LogNorm() float64 {
+	result := 0.0
+
+	// We start at 1 since offset 0 has the sum
+	for i := 1; i < len(f.Variables); i++ {
+		result += numerics.LogRatioNorm(&f.Variables[i].Value, &f.Messages[i].Value)
+	}
+
+	return result
+}
+
+func (f *GaussianWeightedSumFactor) UpdateMessage(i int) float64 {
+	indices := f.varIndexOrders[i]
+
+	// The tricky part here is that we have to put the messages and variables in the same
+	// order as the weights. Thankfully, the weights and messages share the same index numbers,
+	// so we just need to make sure they're consistent
+	msgs := make([]*factorgraphs.Message, len(indices))
+	vars := make([]*factorgraphs.Variable, len(indices))
+	for j, k := range indices {
+		msgs[j] = f.Messages[k]
+		vars[j] = f.Variables[k]
+	}
+
+	return weightedSumUpdateHelper(f.weights[i], f.weightsSqr[i], msgs, vars)
+}
+
+func weightedSumUpdateHelper(weights, weightsSqr []float64, msgs []*factorgraphs.Message, vars []*factorgraphs.Variable) float64 {
+	// Potentially look at http://mathworld.wolfram.com/NormalSumDistribution.html for clues as
+	// to what it's doing
+
+	message0 := msgs[0].Value
+	marginal0 := vars[0].Value
+
+	// The math works out so that 1/newPrecision = sum of a_i^2 /marginalsWithoutMessages[i]
+	invNewPrecisionSum := 0.0
+	weightedMeanSum := 0.0
+
+	for i := range weightsSqr {
+		// These flow directly from the paper
+		marginal, message := &vars[i+1].Value, &msgs[i+1].Value
+		precisionDiff := marginal.Precision - message.Precision
+
+		invNewPrecisionSum += weightsSqr[i] / precisionDiff
+		weightedMeanSum += weights[i] * (marginal.PrecisionMean - message.PrecisionMean) / precisionDiff
+	}
+
+	newPrecision := 1 / invNewPrecisionSum
+	newPrecisionMean := newPrecision * weightedMeanSum
+
+	newMessage := numerics.NewGaussDistFromPrecisionMean(newPrecisionMean, newPrecision)
+	oldMarginalWithoutMessage := new(numerics.GaussDist).Div(&marginal0, &message0)
+	newMarginal := new(numerics.GaussDist).Mul(oldMarginalWithoutMessage, newMessage)
+
+	// Update the message and marginal
+	msgs[0].Value = *newMessage
+	vars[0].Value = *newMarginal
+
+	// Return the difference in the new marginal
+	return numerics.AbsDiff(newMarginal, &marginal0)
+}
+
+func weightedSumName(sum *factorgraphs.Variable, vars []*factorgraphs.Variable, weights []float64) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%v = ", sum)
+	for i, v := range vars {
+		if i == 0 && weights[i] < 0 {
+			b.WriteString("-")
+		}
+
+		fmt.Fprintf(&b, "%.2f*[%v]", math.Abs(weights[i]), v)
+
+		if i < len(vars)-1 {
+			if weights[i+1] >= 0 {
+				b.WriteString(" + ")
+			} else {
+				b.WriteString(" - ")
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills/factorgraphs"
+	"math"
+	"testing"
+)
+
+func TestGaussianWeightedSumFactor(t *testing.T) {
+	x := newTestVariable("x")
+	y := newTestVariable("y")
+	diff := newTestVariable("diff")
+
+	px := NewGaussianPriorFactor(1, 4, x)
+	py := NewGaussianPriorFactor(3, 16, y)
+	f := NewGaussianWeightedSumFactor(diff, []*factorgraphs.Variable{x, y}, []float64{1, -1})
+
+	if s := f.String(); s != "Factor[Variable[diff] = 1.00*[Variable[x]] - 1.00*[Variable[y]]]" {
+		t.Errorf("f.String() = %q", s)
+	}
+
+	px.UpdateMessage(0)
+	py.UpdateMessage(0)
+
+	// diff = x - y
+	f.UpdateMessage(0)
+	AssertGauss(t, -2, math.Sqrt(20), &diff.Value)
+
+	// Nothing else is known about diff, so x and y keep their priors.
+	f.UpdateMessage(1)
+	f.UpdateMessage(2)
+	AssertGauss(t, 1, 2, &x.Value)
+	AssertGauss(t, 3, 4, &y.Value)
+
+	AssertLogNorm(t, 0, factorgraphs.FactorList{px, py, f})
+}
+
+func TestGaussianWeightedSumFactorSolvesForTerms(t *testing.T) {
+	x := newTestVariable("x")
+	y := newTestVariable("y")
+	diff := newTestVariable("diff")
+
+	pd := NewGaussianPriorFactor(0, 1, diff)
+	py := NewGaussianPriorFactor(3, 16, y)
+	f := NewGaussianWeightedSumFactor(diff, []*factorgraphs.Variable{x, y}, []float64{1, -1})
+
+	pd.UpdateMessage(0)
+	py.UpdateMessage(0)
+
+	// x = diff + y
+	f.UpdateMessage(1)
+	AssertGauss(t, 3, math.Sqrt(17), &x.Value)
+}
+
+func TestGaussianSumFactor(t *testing.T) {
+	x := newTestVariable("x")
+	y := newTestVariable("y")
+	sum := newTestVariable("sum")
+
+	NewGaussianPriorFactor(1, 4, x).UpdateMessage(0)
+	NewGaussianPriorFactor(3, 16, y).UpdateMessage(0)
+	NewGaussianSumFactor(sum, []*factorgraphs.Variable{x, y}).UpdateMessage(0)
+
+	AssertGauss(t, 4, math.Sqrt(20), &sum.Value)
+}
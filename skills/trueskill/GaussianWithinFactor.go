@@ -0,0 +1,63 @@
+package trueskill
+
+import (
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills/factorgraphs"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+)
+
+// Factor representing a team difference that has not exceeded the draw margin.
+// See the accompanying math paper for more details.
+type GaussianWithinFactor struct {
+	factorgraphs.FactorBase
+	epsilon float64
+}
+
+func NewGaussianWithinFactor(epsilon float64, v *factorgraphs.Variable) *GaussianWithinFactor {
+	f := &GaussianWithinFactor{
+		FactorBase: factorgraphs.NewFactorBase(fmt.Sprintf("%v <= %.3f", v, epsilon)),
+		epsilon:    epsilon,
+	}
+	f.BindVariable(v)
+	return f
+}
+
+func (f *GaussianWithinFactor) LogNorm() float64 {
+	marginal := &f.Variables[0].Value
+	message := &f.Messages[0].Value
+	messageFromVariable := new(numerics.GaussDist).Div(marginal, message)
+	mean := messageFromVariable.Mean
+	std := messageFromVariable.Stddev
+	z := numerics.GaussCumulativeTo((f.epsilon-mean)/std) - numerics.GaussCumulativeTo((-f.epsilon-mean)/std)
+	return -numerics.LogProdNorm(messageFromVariable, message) + math.Log(z)
+}
+
+func (f *GaussianWithinFactor) UpdateMessage(i int) float64 {
+	m, v := f.Messages[i], f.Variables[i]
+	oldMarginal, oldMessage := v.Value, m.Value
+	messageFromVariable := new(numerics.GaussDist).Div(&oldMarginal, &oldMessage)
+
+	c := messageFromVariable.Precision
+	d := messageFromVariable.PrecisionMean
+
+	sqrtC := math.Sqrt(c)
+	dOnSqrtC := d / sqrtC
+	epsilonTimesSqrtC := f.epsilon * sqrtC
+
+	denom := 1 - numerics.WWithinMargin(dOnSqrtC, epsilonTimesSqrtC)
+
+	newPrecision := c / denom
+	newPrecisionMean := (d + sqrtC*numerics.VWithinMargin(dOnSqrtC, epsilonTimesSqrtC)) / denom
+
+	newMarginal := numerics.NewGaussDistFromPrecisionMean(newPrecisionMean, newPrecision)
+	newMessage := new(numerics.GaussDist).Mul(&oldMessage, newMarginal)
+	newMessage.Div(newMessage, &oldMarginal)
+
+	// Update the message and marginal
+	m.Value = *newMessage
+	v.Value = *newMarginal
+
+	// Return the difference in the new marginal
+	return numerics.AbsDiff(newMarginal, &oldMarginal)
+}
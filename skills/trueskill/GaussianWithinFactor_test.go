@@ -0,0 +1,38 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills/factorgraphs"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+	"testing"
+)
+
+func TestGaussianWithinFactor(t *testing.T) {
+	diff := newTestVariable("diff")
+	prior := NewGaussianPriorFactor(0, 1, diff)
+	f := NewGaussianWithinFactor(1, diff)
+
+	prior.UpdateMessage(0)
+	f.UpdateMessage(0)
+
+	// The moments of a standard normal truncated to -1 <= x <= 1
+	z := numerics.GaussCumulativeTo(1) - numerics.GaussCumulativeTo(-1)
+	variance := 1 - 2*numerics.GaussAt(1)/z
+	AssertGauss(t, 0, math.Sqrt(variance), &diff.Value)
+
+	AssertLogNorm(t, math.Log(z), factorgraphs.FactorList{prior, f})
+}
+
+func TestGaussianWithinFactorLogNorm(t *testing.T) {
+	const mean, stddev, epsilon = 0.5, 2.0, 0.75
+
+	diff := newTestVariable("diff")
+	prior := NewGaussianPriorFactor(mean, stddev*stddev, diff)
+	f := NewGaussianWithinFactor(epsilon, diff)
+
+	prior.UpdateMessage(0)
+	f.UpdateMessage(0)
+
+	expected := math.Log(numerics.GaussCumulativeTo((epsilon-mean)/stddev) - numerics.GaussCumulativeTo((-epsilon-mean)/stddev))
+	AssertLogNorm(t, expected, factorgraphs.FactorList{prior, f})
+}
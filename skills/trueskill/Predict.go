@@ -0,0 +1,109 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+)
+
+// Returns the distribution of a team's performance: the partial play
+// weighted sum of its players' skills plus their performance noise.
+func teamPerformance(gi *skills.GameInfo, t skills.Team) *numerics.GaussDist {
+	meanSum, varSum, weightSqrSum := teamWeightedSums(t)
+	return numerics.NewGaussDist(meanSum, math.Sqrt(varSum+weightSqrSum*numerics.Sqr(gi.Beta)))
+}
+
+func predictOutcome(gi *skills.GameInfo, team1, team2 skills.Team) skills.Prediction {
+	drawMargin := teamsDrawMargin(gi, team1, team2)
+
+	// The first team wins if its performance exceeds the second's by more
+	// than the draw margin
+	diff := new(numerics.GaussDist).Sub(teamPerformance(gi, team1), teamPerformance(gi, team2))
+	lose := diff.CumulativeTo(-drawMargin)
+	notWin := diff.CumulativeTo(drawMargin)
+
+	return skills.Prediction{
+		Win:  1 - notWin,
+		Draw: notWin - lose,
+		Lose: lose,
+	}
+}
+
+func predictRanks(gi *skills.GameInfo, teams []skills.Team) [][]float64 {
+	margins := teamsDrawMargins(gi, teams)
+
+	perfs := make([]*numerics.GaussDist, len(teams))
+	for i, t := range teams {
+		perfs[i] = teamPerformance(gi, t)
+	}
+
+	ranks := make([][]float64, len(teams))
+	for i, pi := range perfs {
+		ranks[i] = make([]float64, len(teams))
+
+		// Given team i's performance the other teams beat it independently
+		// of each other, so the number that do is a sum of independent
+		// trials. Integrating over team i's performance makes it exact.
+		forGaussNodes(func(z, weight float64) {
+			x := pi.Mean + z*pi.Stddev
+
+			beaten := make([]float64, 1, len(teams))
+			beaten[0] = 1
+			for j, pj := range perfs {
+				if j != i {
+					beaten = addTrial(beaten, 1-pj.CumulativeTo(x+margins[i][j]))
+				}
+			}
+
+			for k, p := range beaten {
+				ranks[i][k] += weight * p
+			}
+		})
+	}
+
+	return ranks
+}
+
+// Returns the distribution of the number of successes after one more trial
+// with success probability p, given the distribution before it.
+func addTrial(dist []float64, p float64) []float64 {
+	dist = append(dist, 0)
+	for k := len(dist) - 1; k > 0; k-- {
+		dist[k] = dist[k]*(1-p) + dist[k-1]*p
+	}
+	dist[0] *= 1 - p
+	return dist
+}
+
+// The nodes of a composite Simpson's rule over a standard Gaussian, which is
+// plenty for the smooth integrands here.
+const (
+	gaussNodeRange     = 8.0
+	gaussNodeIntervals = 256
+)
+
+// Calls f with points z spread over a standard Gaussian and weights summing to
+// 1, so that the sum of weight*g(z) approximates the expected value of g.
+func forGaussNodes(f func(z, weight float64)) {
+	h := 2 * gaussNodeRange / gaussNodeIntervals
+
+	total := 0.0
+	weights := make([]float64, gaussNodeIntervals+1)
+	for k := range weights {
+		coef := 2.0
+		switch {
+		case k == 0 || k == gaussNodeIntervals:
+			coef = 1
+		case k%2 == 1:
+			coef = 4
+		}
+		weights[k] = coef * numerics.GaussAt(-gaussNodeRange+float64(k)*h)
+		total += weights[k]
+	}
+
+	for k, w := range weights {
+		f(-gaussNodeRange+float64(k)*h, w/total)
+	}
+}
+
+var predictOutcomeTeamRange = numerics.Exactly(2)
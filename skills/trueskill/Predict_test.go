@@ -0,0 +1,138 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+	"testing"
+)
+
+const predictTolerance = 1e-6
+
+func AllTwoPlayerPredictionScenarios(t *testing.T, calc skills.Predictor) {
+	EvenOneOnOnePrediction(t, calc)
+	UnevenOneOnOnePrediction(t, calc)
+	PredictOutcomeMatchesRanks(t, calc)
+	PredictionInvalidInput(t, calc)
+}
+
+func AllMultipleTeamPredictionScenarios(t *testing.T, calc skills.Predictor) {
+	ThreeEvenTeamsRanks(t, calc)
+	FourUnevenTeamsRanks(t, calc)
+}
+
+func EvenOneOnOnePrediction(t *testing.T, calc skills.Predictor) {
+	gi := skills.DefaultGameInfo()
+
+	// Players whose skill is known exactly draw as often as the game does
+	certain := skills.NewRating(25, 1e-6)
+	p := calc.PredictOutcome(gi, teamsOfOne(certain, certain))
+	assertNear(t, "Draw", p.Draw, gi.DrawProbability)
+	assertNear(t, "Win", p.Win, p.Lose)
+
+	// Uncertainty makes a draw less likely
+	p = calc.PredictOutcome(gi, teamsOfOne(defaultRatings(gi, 2)...))
+	if p.Draw >= gi.DrawProbability {
+		t.Errorf("Draw = %v, want less than %v\n%v", p.Draw, gi.DrawProbability, testLoc())
+	}
+	assertNear(t, "Win", p.Win, p.Lose)
+	assertNear(t, "total", p.Win+p.Draw+p.Lose, 1)
+}
+
+func UnevenOneOnOnePrediction(t *testing.T, calc skills.Predictor) {
+	gi := skills.DefaultGameInfo()
+	strong, weak := skills.NewRating(30, 3), skills.NewRating(20, 3)
+
+	p := calc.PredictOutcome(gi, teamsOfOne(strong, weak))
+	q := calc.PredictOutcome(gi, teamsOfOne(weak, strong))
+
+	if p.Win <= p.Lose {
+		t.Errorf("Win = %v, want more than Lose = %v\n%v", p.Win, p.Lose, testLoc())
+	}
+	assertNear(t, "Win", p.Win, q.Lose)
+	assertNear(t, "Draw", p.Draw, q.Draw)
+
+	// Performances differ by N(10, 2*3^2 + 2*beta^2) and must exceed the draw margin
+	c := math.Sqrt(2*9 + 2*numerics.Sqr(gi.Beta))
+	margin := drawMarginFromDrawProbability(gi.DrawProbability, gi.Beta)
+	assertNear(t, "Win", p.Win, 1-numerics.GaussCumulativeTo((margin-10)/c))
+}
+
+func PredictOutcomeMatchesRanks(t *testing.T, calc skills.Predictor) {
+	gi := skills.DefaultGameInfo()
+	teams := teamsOfOne(skills.NewRating(27, 4), skills.NewRating(24, 6))
+
+	p := calc.PredictOutcome(gi, teams)
+	ranks := calc.PredictRanks(gi, teams)
+
+	// A draw puts both teams first
+	assertNear(t, "ranks[0][0]", ranks[0][0], p.Win+p.Draw)
+	assertNear(t, "ranks[0][1]", ranks[0][1], p.Lose)
+	assertNear(t, "ranks[1][0]", ranks[1][0], p.Lose+p.Draw)
+	assertNear(t, "ranks[1][1]", ranks[1][1], p.Win)
+}
+
+func PredictionInvalidInput(t *testing.T, calc skills.Predictor) {
+	gi := skills.DefaultGameInfo()
+
+	_, err := calc.TryPredictOutcome(gi, teamsOfOne(defaultRatings(gi, 3)...))
+	var teamErr *skills.TeamCountError
+	AssertErrorAs(t, err, &teamErr)
+
+	_, err = calc.TryPredictRanks(gi, teamsOfOne(defaultRatings(gi, 1)...))
+	AssertErrorAs(t, err, &teamErr)
+
+	_, err = calc.TryPredictOutcome(gi, teamsOfOne(skills.NewRating(math.NaN(), 1), gi.DefaultRating()))
+	var ratingErr *skills.RatingError
+	AssertErrorAs(t, err, &ratingErr)
+}
+
+func ThreeEvenTeamsRanks(t *testing.T, calc skills.Predictor) {
+	gi := *skills.DefaultGameInfo()
+	gi.DrawProbability = 0
+
+	// Without draws every order is equally likely
+	for i, row := range calc.PredictRanks(&gi, teamsOfOne(defaultRatings(&gi, 3)...)) {
+		for k, p := range row {
+			if math.Abs(p-1.0/3) > predictTolerance {
+				t.Errorf("ranks[%v][%v] = %v, want 1/3\n%v", i, k, p, testLoc())
+			}
+		}
+	}
+}
+
+func FourUnevenTeamsRanks(t *testing.T, calc skills.Predictor) {
+	gi := skills.DefaultGameInfo()
+	teams := teamsOfOne(skills.NewRating(35, 3), skills.NewRating(28, 4), skills.NewRating(25, 8), skills.NewRating(15, 2))
+
+	ranks := calc.PredictRanks(gi, teams)
+
+	for i, row := range ranks {
+		sum := 0.0
+		for _, p := range row {
+			sum += p
+		}
+		assertNear(t, "row sum", sum, 1)
+		if i > 0 && row[0] >= ranks[i-1][0] {
+			t.Errorf("team %v wins with %v, want less than team %v's %v\n%v", i, row[0], i-1, ranks[i-1][0], testLoc())
+		}
+	}
+
+	// Without draws each position is filled exactly once
+	noDraws := *gi
+	noDraws.DrawProbability = 0
+	ranks = calc.PredictRanks(&noDraws, teams)
+	for k := range ranks {
+		sum := 0.0
+		for i := range ranks {
+			sum += ranks[i][k]
+		}
+		assertNear(t, "column sum", sum, 1)
+	}
+}
+
+func assertNear(t *testing.T, what string, got, want float64) {
+	if math.Abs(got-want) > predictTolerance {
+		t.Errorf("%v = %v, want %v\n%v", what, got, want, testLoc())
+	}
+}
@@ -0,0 +1,198 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+	"sort"
+)
+
+// Calculates TrueSkill from scores as well as ranks, so a 10-0 win moves
+// ratings more than a 10-9 win. The score margin between each pair of teams
+// adjacent in the final order is treated as a noisy observation of the
+// difference in their performances, and the ratings that result are then
+// updated from the ranks the scores imply.
+//
+// The margin observations are applied one pair at a time without tracking
+// the correlations they introduce, the same approximation the factor graph
+// makes between its layers.
+//
+// Given ranks alone it passes them straight to Calc.
+type ScoreMarginCalc struct {
+	// Updates ratings from the ranks; nil means FactorGraphCalc. Like the
+	// calculators in this module, it must apply partial updates with
+	// skills.ApplyPartialUpdates.
+	Calc skills.TryCalc
+
+	// The performance difference one point of margin stands for; 0 means
+	// gi.Beta. Games with high, noisy scores want a much smaller value.
+	PointValue float64
+
+	// The standard deviation of a margin observation beyond the players'
+	// own performance noise; 0 means gi.Beta.
+	MarginNoise float64
+}
+
+// Calculates new ratings based on the prior ratings and team ranks use 1 for first place, repeat the number for a tie (e.g. 1, 2, 2).
+func (calc *ScoreMarginCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatings but returns an error instead of panicking on invalid input.
+func (calc *ScoreMarginCalc) TryCalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) (skills.PlayerRatings, error) {
+	return calc.calc().TryCalcNewRatings(gi, teams, ranks...)
+}
+
+// Calculates new ratings based on the prior ratings and the points each team scored.
+func (calc *ScoreMarginCalc) CalcNewRatingsFromScores(gi *skills.GameInfo, teams []skills.Team, scores skills.Scores) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatingsFromScores(gi, teams, scores)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatingsFromScores but returns an error instead of panicking on invalid input.
+func (calc *ScoreMarginCalc) TryCalcNewRatingsFromScores(gi *skills.GameInfo, teams []skills.Team, scores skills.Scores) (skills.PlayerRatings, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, factorGraphTeamRange, factorGraphPlayerRange); err != nil {
+		return nil, err
+	}
+	if err := scores.Validate(); err != nil {
+		return nil, err
+	}
+	pointValue, err := calc.param("PointValue", calc.PointValue, gi.Beta)
+	if err != nil {
+		return nil, err
+	}
+	marginNoise, err := calc.param("MarginNoise", calc.MarginNoise, gi.Beta)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy slices so we don't confuse the client code
+	steams := append([]skills.Team{}, teams...)
+	ranks := scores.Ranks()
+	sranks := append([]int{}, ranks...)
+	sscores := append(skills.Scores{}, scores...)
+
+	rt, err := skills.TryNewRankedTeams(steams, sranks)
+	if err != nil {
+		return nil, err
+	}
+	sort.Stable(&rankedScores{rt, sscores})
+
+	// The skills drift before either kind of evidence is applied, so the
+	// rank update mustn't add the dynamics again
+	marginSkills := make(skills.PlayerRatings)
+	for _, t := range steams {
+		for _, p := range t.Players() {
+			r := t.PlayerRating(p)
+			marginSkills[p] = skills.NewRating(r.Mean(), math.Sqrt(gi.DynamicVariance(p, r)))
+		}
+	}
+
+	for i := 1; i < len(steams); i++ {
+		margin := pointValue * (sscores[i-1] - sscores[i])
+		observeMargin(gi, marginSkills, steams[i-1], steams[i], margin, marginNoise)
+	}
+
+	marginTeams := make([]skills.Team, len(teams))
+	for i, t := range teams {
+		marginTeams[i] = skills.NewTeam()
+		for _, p := range t.Players() {
+			marginTeams[i].AddPlayer(p, marginSkills[p])
+		}
+	}
+
+	newSkills, err := calc.calc().TryCalcNewRatings(withoutDynamics(gi), marginTeams, ranks...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calc damped its update from the margin ratings, but a player should
+	// only receive part of the update from their rating before the match
+	skills.RebasePartialUpdates(marginTeams, teams, newSkills)
+
+	return newSkills, nil
+}
+
+// Updates the ratings of the players on winner and loser given that winner
+// outscored loser by margin, in performance units.
+func observeMargin(gi *skills.GameInfo, ratings skills.PlayerRatings, winner, loser skills.Team, margin, marginNoise float64) {
+	betaSqr := numerics.Sqr(gi.Beta)
+	sides := []struct {
+		team skills.Team
+		sign float64
+	}{{winner, 1}, {loser, -1}}
+
+	predicted := 0.0
+	c2 := numerics.Sqr(marginNoise)
+	for _, side := range sides {
+		for _, p := range side.team.Players() {
+			w := skills.PartialPlayPercentage(p)
+			r := ratings[p]
+			predicted += side.sign * w * r.Mean()
+			c2 += w * w * (r.Variance() + betaSqr)
+		}
+	}
+
+	surprise := margin - predicted
+
+	for _, side := range sides {
+		for _, p := range side.team.Players() {
+			w := skills.PartialPlayPercentage(p)
+			r := ratings[p]
+			newMean := r.Mean() + side.sign*w*r.Variance()/c2*surprise
+			newVar := r.Variance() * (1 - w*w*r.Variance()/c2)
+			ratings[p] = skills.NewRating(newMean, math.Sqrt(newVar))
+		}
+	}
+}
+
+func (calc *ScoreMarginCalc) calc() skills.TryCalc {
+	if calc.Calc == nil {
+		return &FactorGraphCalc{}
+	}
+	return calc.Calc
+}
+
+// Returns v, or def if v is zero.
+func (calc *ScoreMarginCalc) param(field string, v, def float64) (float64, error) {
+	switch {
+	case v == 0:
+		return def, nil
+	case !(v > 0) || math.IsInf(v, 1):
+		return 0, &skills.ScoreMarginError{Field: field, Value: v}
+	}
+	return v, nil
+}
+
+// Calculates the match quality as the likelihood of all teams drawing (0% = bad, 100% = well matched).
+func (calc *ScoreMarginCalc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Team) float64 {
+	r, err := calc.TryCalcMatchQual(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcMatchQual but returns an error instead of panicking on invalid input.
+func (calc *ScoreMarginCalc) TryCalcMatchQual(gi *skills.GameInfo, teams []skills.Team) (float64, error) {
+	return calc.calc().TryCalcMatchQual(gi, teams)
+}
+
+// Sorts ranked teams and keeps their scores alongside.
+type rankedScores struct {
+	*skills.RankedTeams
+	scores skills.Scores
+}
+
+func (rs *rankedScores) Swap(i, j int) {
+	rs.RankedTeams.Swap(i, j)
+	rs.scores[i], rs.scores[j] = rs.scores[j], rs.scores[i]
+}
@@ -0,0 +1,115 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+	"testing"
+)
+
+var _ skills.ScoreCalc = &ScoreMarginCalc{}
+
+func TestScoreMarginCalc(t *testing.T) {
+	AllTwoPlayerScenarios(t, &ScoreMarginCalc{})
+	AllInvalidInputScenarios(t, &ScoreMarginCalc{})
+}
+
+func TestScoreMarginBlowout(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+	calc := &ScoreMarginCalc{PointValue: gi.Beta / 5}
+
+	narrow := calc.CalcNewRatingsFromScores(gi, teamsOfOne(defaultRatings(gi, 2)...), skills.Scores{10, 9})
+	blowout := calc.CalcNewRatingsFromScores(gi, teamsOfOne(defaultRatings(gi, 2)...), skills.Scores{10, 0})
+
+	if narrow[1].Mean() <= 25 || narrow[2].Mean() >= 25 {
+		t.Errorf("narrow win means = %v, %v; want the winner up and the loser down", narrow[1].Mean(), narrow[2].Mean())
+	}
+	if blowout[1].Mean() <= narrow[1].Mean() || blowout[2].Mean() >= narrow[2].Mean() {
+		t.Errorf("blowout means = %v, %v; want them further apart than %v, %v",
+			blowout[1].Mean(), blowout[2].Mean(), narrow[1].Mean(), narrow[2].Mean())
+	}
+
+	// The margin is extra evidence, so it also leaves players more certain
+	ranked := (&FactorGraphCalc{}).CalcNewRatings(gi, teamsOfOne(defaultRatings(gi, 2)...), 1, 2)
+	if narrow[1].Stddev() >= ranked[1].Stddev() {
+		t.Errorf("stddev = %v, want less than the rank-only %v", narrow[1].Stddev(), ranked[1].Stddev())
+	}
+}
+
+func TestScoreMarginOrder(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+	calc := &ScoreMarginCalc{}
+
+	// Scores can be given in any order and can be negative
+	a := calc.CalcNewRatingsFromScores(gi, teamsOfOne(defaultRatings(gi, 3)...), skills.Scores{1, 5, 3})
+	b := calc.CalcNewRatingsFromScores(gi, teamsOfOne(defaultRatings(gi, 3)...), skills.Scores{-5, -1, -3})
+
+	if !(a[2].Mean() > a[3].Mean() && a[3].Mean() > a[1].Mean()) {
+		t.Errorf("means = %v, %v, %v; want player 2 first and player 1 last", a[1].Mean(), a[2].Mean(), a[3].Mean())
+	}
+	for p, r := range a {
+		if math.Abs(b[p].Mean()-r.Mean()) > 1e-9 {
+			t.Errorf("player %v mean = %v, want %v", p, b[p].Mean(), r.Mean())
+		}
+	}
+}
+
+func TestScoreMarginDraw(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+	calc := &ScoreMarginCalc{}
+
+	// A level score says the players are closer than their ratings suggest
+	teams := teamsOfOne(skills.NewRating(30, 5), skills.NewRating(20, 5))
+	newRatings := calc.CalcNewRatingsFromScores(gi, teams, skills.Scores{2, 2})
+	ranked := (&FactorGraphCalc{}).CalcNewRatings(gi, teams, 1, 1)
+
+	if got, want := newRatings[1].Mean()-newRatings[2].Mean(), ranked[1].Mean()-ranked[2].Mean(); got >= want {
+		t.Errorf("gap = %v, want less than the rank-only %v", got, want)
+	}
+	if sum := newRatings[1].Mean() + newRatings[2].Mean(); math.Abs(sum-50) > 1e-6 {
+		t.Errorf("means sum to %v, want 50", sum)
+	}
+}
+
+func TestScoreMarginInvalidInput(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+	teams := teamsOfOne(defaultRatings(gi, 2)...)
+
+	_, err := (&ScoreMarginCalc{}).TryCalcNewRatingsFromScores(gi, teams, skills.Scores{1, math.NaN()})
+	var scoreErr *skills.ScoreError
+	if AssertErrorAs(t, err, &scoreErr) && scoreErr.Team != 1 {
+		t.Errorf("scoreErr.Team = %v, want 1", scoreErr.Team)
+	}
+
+	_, err = (&ScoreMarginCalc{}).TryCalcNewRatingsFromScores(gi, teams, skills.Scores{1})
+	var rankErr *skills.RankCountError
+	AssertErrorAs(t, err, &rankErr)
+
+	_, err = (&ScoreMarginCalc{PointValue: -1}).TryCalcNewRatingsFromScores(gi, teams, skills.Scores{1, 0})
+	var calcErr *skills.ScoreMarginError
+	if AssertErrorAs(t, err, &calcErr) && calcErr.Field != "PointValue" {
+		t.Errorf("calcErr.Field = %v, want PointValue", calcErr.Field)
+	}
+}
+
+func TestScoreMarginPartialUpdate(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+	calc := &ScoreMarginCalc{}
+	prior := gi.DefaultRating()
+
+	full := calc.CalcNewRatingsFromScores(gi, teamsOfOne(prior, prior), skills.Scores{3, 10})
+
+	sub := skills.NewPartialUpdatePlayer(1, 0.5)
+	teams := []skills.Team{skills.NewTeam(), skills.NewTeam()}
+	teams[0].AddPlayer(sub, prior)
+	teams[1].AddPlayer(2, prior)
+	got := calc.CalcNewRatingsFromScores(gi, teams, skills.Scores{3, 10})
+
+	// The update is damped once, from the rating before the match
+	want := skills.PartialUpdateRating(prior, full[1], 0.5)
+	if r := got[sub]; math.Abs(r.Mean()-want.Mean()) > 1e-9 || math.Abs(r.Stddev()-want.Stddev()) > 1e-9 {
+		t.Errorf("partial update rating = %v, want %v", r, want)
+	}
+	if r := got[2]; math.Abs(r.Mean()-full[2].Mean()) > 1e-9 || math.Abs(r.Stddev()-full[2].Stddev()) > 1e-9 {
+		t.Errorf("full update rating = %v, want %v", r, full[2])
+	}
+}
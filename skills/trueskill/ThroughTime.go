@@ -0,0 +1,373 @@
+package trueskill
+
+import (
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+	"sort"
+)
+
+const (
+	DefaultThroughTimeIterations = 30
+	DefaultThroughTimeEpsilon    = 1e-6
+)
+
+// Smooths a whole match history with TrueSkill Through Time (Dangauthier et
+// al., NIPS 2007). The other calculators filter: a rating only ever reflects
+// the matches before it. ThroughTime passes messages forward and backward in
+// time until they settle, so every estimate, early ones included, uses all of
+// the evidence.
+//
+// Each player has one skill per time step they played in. Between steps the
+// skill drifts by DynamicsFactor per unit of time, but as with
+// GameInfo.DynamicVariance, a long gap never takes the standard deviation
+// past InitialStddev.
+type ThroughTime struct {
+	// Rates each match given the current estimate of its players' skills;
+	// nil means FactorGraphCalc. The match's message to each player is the
+	// calculator's posterior divided by the prior it was given.
+	Calc skills.TryCalc
+
+	// The maximum number of forward and backward sweeps over the history; 0
+	// means DefaultThroughTimeIterations.
+	Iterations int
+
+	// Sweeping stops once no message changes by more than this; 0 means
+	// DefaultThroughTimeEpsilon.
+	Epsilon float64
+}
+
+// A match in a history.
+type Match struct {
+	Time  int             // matches with the same time share one skill per player
+	Teams [][]interface{} // the players on each team
+	Ranks []int           // as for skills.Calc
+}
+
+// A player's smoothed rating at one time step.
+type TimedRating struct {
+	Time int
+	skills.Rating
+}
+
+// Each player's smoothed ratings in time order.
+type SkillHistory map[interface{}][]TimedRating
+
+// Returns each player's rating at the last time step they played in, ready to
+// carry on with a filtering calculator.
+func (h SkillHistory) Latest() skills.PlayerRatings {
+	latest := make(skills.PlayerRatings)
+	for p, rs := range h {
+		latest[p] = rs[len(rs)-1].Rating
+	}
+	return latest
+}
+
+// Returned when a match in a history can't be rated; shared with
+// skills.Replay.
+type MatchError = skills.MatchError
+
+// Returns the smoothed skill history for matches, which may be given in any
+// order. Players missing from priors start at gi's default rating.
+func (tt *ThroughTime) Smooth(gi *skills.GameInfo, priors skills.PlayerRatings, matches []Match) SkillHistory {
+	h, err := tt.TrySmooth(gi, priors, matches)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// Like Smooth but returns an error instead of panicking on invalid input.
+func (tt *ThroughTime) TrySmooth(gi *skills.GameInfo, priors skills.PlayerRatings, matches []Match) (SkillHistory, error) {
+	if err := gi.Validate(); err != nil {
+		return nil, err
+	}
+
+	// The dynamics are applied between time steps, so the calculator
+	// mustn't add them again
+	s := &ttSmoother{
+		tt:      tt,
+		gi:      withoutDynamics(gi),
+		tauSqr:  numerics.Sqr(gi.DynamicsFactor),
+		ceiling: numerics.Sqr(gi.InitialStddev),
+		matches: matches,
+	}
+	s.buildBatches(gi, priors)
+
+	for i := 0; i < tt.iterations(); i++ {
+		delta := 0.0
+		for _, b := range s.batches {
+			d, err := s.sweep(b, true)
+			if err != nil {
+				return nil, err
+			}
+			delta = math.Max(delta, d)
+		}
+		for j := len(s.batches) - 1; j >= 0; j-- {
+			d, err := s.sweep(s.batches[j], false)
+			if err != nil {
+				return nil, err
+			}
+			delta = math.Max(delta, d)
+		}
+		if delta < tt.epsilon() {
+			break
+		}
+	}
+
+	h := make(SkillHistory)
+	for _, b := range s.batches {
+		for _, p := range b.players {
+			sk := b.skills[p]
+			post := sk.posterior(-1)
+			h[p] = append(h[p], TimedRating{b.time, skills.NewRating(post.Mean, post.Stddev)})
+		}
+	}
+	return h, nil
+}
+
+func (tt *ThroughTime) calc() skills.TryCalc {
+	if tt.Calc == nil {
+		return &FactorGraphCalc{}
+	}
+	return tt.Calc
+}
+
+func (tt *ThroughTime) iterations() int {
+	if tt.Iterations == 0 {
+		return DefaultThroughTimeIterations
+	}
+	return tt.Iterations
+}
+
+func (tt *ThroughTime) epsilon() float64 {
+	if tt.Epsilon == 0 {
+		return DefaultThroughTimeEpsilon
+	}
+	return tt.Epsilon
+}
+
+type ttSmoother struct {
+	tt      *ThroughTime
+	gi      *skills.GameInfo
+	tauSqr  float64
+	ceiling float64 // the most variance time away can add up to
+	matches []Match
+	batches []*ttBatch
+}
+
+// The matches at one time step.
+type ttBatch struct {
+	time    int
+	matches []int // indexes into the history
+	players []interface{}
+	skills  map[interface{}]*ttSkill
+}
+
+// A player's skill at one time step.
+type ttSkill struct {
+	time       int
+	prior      *numerics.GaussDist // only set at the player's first time step
+	prev, next *ttSkill
+	forward    *numerics.GaussDist // from the prior or the previous time step
+	backward   *numerics.GaussDist // from the next time step
+	messages   []ttMessage         // in match order so products are reproducible
+}
+
+// The message to a skill from one match.
+type ttMessage struct {
+	match int
+	dist  *numerics.GaussDist
+}
+
+func (s *ttSmoother) buildBatches(gi *skills.GameInfo, priors skills.PlayerRatings) {
+	order := make([]int, len(s.matches))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return s.matches[order[i]].Time < s.matches[order[j]].Time
+	})
+
+	last := make(map[interface{}]*ttSkill)
+	var b *ttBatch
+	for _, m := range order {
+		match := s.matches[m]
+		if b == nil || b.time != match.Time {
+			b = &ttBatch{time: match.Time, skills: make(map[interface{}]*ttSkill)}
+			s.batches = append(s.batches, b)
+		}
+		b.matches = append(b.matches, m)
+
+		for _, t := range match.Teams {
+			for _, p := range t {
+				sk := b.skills[p]
+				if sk == nil {
+					sk = &ttSkill{
+						time:     b.time,
+						prev:     last[p],
+						backward: uninformative(),
+					}
+					if sk.prev != nil {
+						sk.prev.next = sk
+					} else {
+						r, ok := priors[p]
+						if !ok {
+							r = gi.DefaultRating()
+						}
+						sk.prior = numerics.NewGaussDist(r.Mean(), r.Stddev())
+					}
+					sk.forward = s.forwardMessage(sk)
+					b.skills[p] = sk
+					b.players = append(b.players, p)
+					last[p] = sk
+				}
+				if n := len(sk.messages); n == 0 || sk.messages[n-1].match != m {
+					sk.messages = append(sk.messages, ttMessage{m, uninformative()})
+				}
+			}
+		}
+	}
+}
+
+// Updates the messages into batch b from the previous time step if forward,
+// or from the next one if not, then rates b's matches. Returns the largest
+// change to any message.
+func (s *ttSmoother) sweep(b *ttBatch, forward bool) (float64, error) {
+	delta := 0.0
+	for _, p := range b.players {
+		sk := b.skills[p]
+		if forward {
+			msg := s.forwardMessage(sk)
+			delta = math.Max(delta, numerics.AbsDiff(sk.forward, msg))
+			sk.forward = msg
+		} else {
+			msg := s.backwardMessage(sk)
+			delta = math.Max(delta, numerics.AbsDiff(sk.backward, msg))
+			sk.backward = msg
+		}
+	}
+
+	// Matches in the same batch share skills, so they are rated in turn
+	// until their messages to each other settle
+	for i := 0; i < s.tt.iterations(); i++ {
+		batchDelta := 0.0
+		for _, m := range b.matches {
+			d, err := s.rateMatch(b, m)
+			if err != nil {
+				return 0, &MatchError{Match: m, Err: err}
+			}
+			batchDelta = math.Max(batchDelta, d)
+		}
+		delta = math.Max(delta, batchDelta)
+		if len(b.matches) == 1 || batchDelta < s.tt.epsilon() {
+			break
+		}
+	}
+
+	return delta, nil
+}
+
+// Rates match m against its players' skills excluding what m itself has
+// already contributed, and returns the largest change to m's messages.
+func (s *ttSmoother) rateMatch(b *ttBatch, m int) (float64, error) {
+	match := s.matches[m]
+
+	teams := make([]skills.Team, len(match.Teams))
+	priors := make(map[interface{}]*numerics.GaussDist)
+	for i, t := range match.Teams {
+		teams[i] = skills.NewTeam()
+		for _, p := range t {
+			prior := b.skills[p].posterior(m)
+			priors[p] = prior
+			teams[i].AddPlayer(p, skills.NewRating(prior.Mean, prior.Stddev))
+		}
+	}
+
+	newRatings, err := s.tt.calc().TryCalcNewRatings(s.gi, teams, match.Ranks...)
+	if err != nil {
+		return 0, err
+	}
+
+	delta := 0.0
+	for p, prior := range priors {
+		r := newRatings[p]
+		post := numerics.NewGaussDist(r.Mean(), r.Stddev())
+		msg := new(numerics.GaussDist).Div(post, prior)
+		if msg.Precision < 0 {
+			// A calculator that approximates can leave a player slightly
+			// less certain than before; treat the match as saying nothing.
+			msg = uninformative()
+		}
+		old := b.skills[p].message(m)
+		delta = math.Max(delta, numerics.AbsDiff(old.dist, msg))
+		old.dist = msg
+	}
+	return delta, nil
+}
+
+// The message into sk from its prior or the previous time step.
+func (s *ttSmoother) forwardMessage(sk *ttSkill) *numerics.GaussDist {
+	if sk.prev == nil {
+		// As when filtering, the skill drifts before the first match too
+		return s.drift(sk.prior, 1)
+	}
+	d := new(numerics.GaussDist).Mul(sk.prev.forward, sk.prev.likelihood())
+	return s.drift(d, sk.time-sk.prev.time)
+}
+
+// The message into sk from the next time step.
+func (s *ttSmoother) backwardMessage(sk *ttSkill) *numerics.GaussDist {
+	if sk.next == nil {
+		return uninformative()
+	}
+	d := new(numerics.GaussDist).Mul(sk.next.backward, sk.next.likelihood())
+	return s.drift(d, sk.next.time-sk.time)
+}
+
+// Returns the product of the messages from sk's matches.
+func (sk *ttSkill) likelihood() *numerics.GaussDist {
+	return sk.product(-1, uninformative())
+}
+
+// Returns the skill's estimate from everything except match exclude, or from
+// everything if exclude is -1.
+func (sk *ttSkill) posterior(exclude int) *numerics.GaussDist {
+	d := new(numerics.GaussDist).Mul(sk.forward, sk.backward)
+	return sk.product(exclude, d)
+}
+
+func (sk *ttSkill) product(exclude int, d *numerics.GaussDist) *numerics.GaussDist {
+	for _, msg := range sk.messages {
+		if msg.match != exclude {
+			d.Mul(d, msg.dist)
+		}
+	}
+	return d
+}
+
+func (sk *ttSkill) message(m int) *ttMessage {
+	for i := range sk.messages {
+		if sk.messages[i].match == m {
+			return &sk.messages[i]
+		}
+	}
+	panic(fmt.Sprintf("no message from matches[%v]", m))
+}
+
+// Returns d after steps time steps of drift. Like GameInfo.DynamicVariance,
+// the first step always adds its drift, and the rest add theirs only up to
+// the ceiling.
+func (s *ttSmoother) drift(d *numerics.GaussDist, steps int) *numerics.GaussDist {
+	if d.Precision == 0 {
+		return uninformative()
+	}
+	v := d.Variance + s.tauSqr
+	v = math.Max(v, math.Min(d.Variance+float64(steps)*s.tauSqr, s.ceiling))
+	return numerics.NewGaussDist(d.Mean, math.Sqrt(v))
+}
+
+func uninformative() *numerics.GaussDist {
+	return numerics.NewGaussDistFromPrecisionMean(0, 0)
+}
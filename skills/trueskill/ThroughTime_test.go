@@ -0,0 +1,122 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+	"testing"
+)
+
+func TestThroughTimeSingleMatch(t *testing.T) {
+	// With nothing to smooth the result is the filtered one
+	gi := skills.DefaultGameInfo()
+	h := (&ThroughTime{}).Smooth(gi, nil, []Match{
+		{Time: 1, Teams: [][]interface{}{{1}, {2}}, Ranks: []int{1, 2}},
+	})
+
+	if len(h[1]) != 1 || h[1][0].Time != 1 {
+		t.Fatalf("h[1] = %v, want one rating at time 1", h[1])
+	}
+	AssertRating(t, 29.396, 7.171, h[1][0].Rating)
+	AssertRating(t, 20.604, 7.171, h[2][0].Rating)
+}
+
+func TestThroughTimeUsesLaterEvidence(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+	calc := &TwoPlayerCalc{}
+	tt := &ThroughTime{}
+
+	// Player 1 beats player 2 and then player 3, who has beaten player 2 too
+	matches := []Match{
+		{Time: 1, Teams: [][]interface{}{{1}, {2}}, Ranks: []int{1, 2}},
+		{Time: 2, Teams: [][]interface{}{{3}, {2}}, Ranks: []int{1, 2}},
+		{Time: 3, Teams: [][]interface{}{{1}, {3}}, Ranks: []int{1, 2}},
+	}
+	h := tt.Smooth(gi, nil, matches)
+
+	filtered := calc.CalcNewRatings(gi, teamsOfOne(gi.DefaultRating(), gi.DefaultRating()), 1, 2)
+	if got, filteredMean := h[1][0].Mean(), filtered[1].Mean(); got <= filteredMean {
+		t.Errorf("smoothed mean at time 1 = %v, want above the filtered %v", got, filteredMean)
+	}
+	if got, filteredStddev := h[1][0].Stddev(), filtered[1].Stddev(); got >= filteredStddev {
+		t.Errorf("smoothed stddev at time 1 = %v, want below the filtered %v", got, filteredStddev)
+	}
+
+	// Skill barely drifts between steps, so player 1's estimates agree
+	if d := math.Abs(h[1][0].Mean() - h[1][1].Mean()); d > 3*gi.DynamicsFactor {
+		t.Errorf("player 1 mean moved by %v between time steps", d)
+	}
+
+	// The order matches are given in doesn't matter
+	reversed := []Match{matches[2], matches[1], matches[0]}
+	for p, rs := range tt.Smooth(gi, nil, reversed) {
+		for i, r := range rs {
+			if want := h[p][i]; r != want {
+				t.Errorf("player %v at time %v = %v, want %v", p, r.Time, r, want)
+			}
+		}
+	}
+
+	latest := h.Latest()
+	if latest[1] != h[1][1].Rating || latest[2] != h[2][1].Rating {
+		t.Errorf("Latest() = %v, want the last rating of each player", latest)
+	}
+}
+
+func TestThroughTimeLongGap(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+
+	// However long player 1 is away they are never less certain than a new
+	// player going into their next match, so a match leaves them more certain
+	h := (&ThroughTime{}).Smooth(gi, nil, []Match{
+		{Time: 1, Teams: [][]interface{}{{1}, {2}}, Ranks: []int{1, 2}},
+		{Time: 1000000, Teams: [][]interface{}{{1}, {3}}, Ranks: []int{1, 2}},
+	})
+
+	for _, r := range h[1] {
+		if r.Stddev() >= gi.InitialStddev {
+			t.Errorf("stddev at time %v = %v, want below %v", r.Time, r.Stddev(), gi.InitialStddev)
+		}
+	}
+}
+
+func TestThroughTimeSameTimeStep(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+
+	// Two wins in one time step count as two wins for a single skill
+	h := (&ThroughTime{}).Smooth(gi, nil, []Match{
+		{Time: 1, Teams: [][]interface{}{{1}, {2}}, Ranks: []int{1, 2}},
+		{Time: 1, Teams: [][]interface{}{{1}, {2}}, Ranks: []int{1, 2}},
+	})
+
+	if len(h[1]) != 1 {
+		t.Fatalf("h[1] = %v, want one rating", h[1])
+	}
+	if got := h[1][0].Mean(); got <= 29.396 {
+		t.Errorf("mean = %v, want more than one win's worth", got)
+	}
+	if sum := h[1][0].Mean() + h[2][0].Mean(); math.Abs(sum-50) > 1e-6 {
+		t.Errorf("means sum to %v, want 50", sum)
+	}
+}
+
+func TestThroughTimeInvalidInput(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+	tt := &ThroughTime{}
+
+	_, err := tt.TrySmooth(gi, nil, []Match{
+		{Time: 1, Teams: [][]interface{}{{1}, {2}}, Ranks: []int{1, 2}},
+		{Time: 0, Teams: [][]interface{}{{1}}, Ranks: []int{1}},
+	})
+	var matchErr *MatchError
+	if AssertErrorAs(t, err, &matchErr) && matchErr.Match != 1 {
+		t.Errorf("matchErr.Match = %v, want 1", matchErr.Match)
+	}
+	var teamErr *skills.TeamCountError
+	AssertErrorAs(t, err, &teamErr)
+
+	bad := *gi
+	bad.Beta = 0
+	_, err = tt.TrySmooth(&bad, nil, nil)
+	var giErr *skills.GameInfoError
+	AssertErrorAs(t, err, &giErr)
+}
@@ -14,18 +14,33 @@ type TwoPlayerCalc struct{}
 
 // Calculates new ratings based on the prior ratings and team ranks use 1 for first place, repeat the number for a tie (e.g. 1, 2, 2).
 func (calc *TwoPlayerCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatings but returns an error instead of panicking on invalid input.
+func (calc *TwoPlayerCalc) TryCalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) (skills.PlayerRatings, error) {
 	newSkills := make(map[interface{}]skills.Rating)
 
 	// Basic argument checking
-	validateTeamCount(teams, twoPlayerTeamRange)
-	validatePlayersPerTeam(teams, twoPlayerPlayerRange)
+	if err := skills.ValidateTeams(gi, teams, twoPlayerTeamRange, twoPlayerPlayerRange); err != nil {
+		return nil, err
+	}
 
 	// Copy the slices so we don't confuse the client code
 	steams := append([]skills.Team{}, teams...)
 	sranks := append([]int{}, ranks...)
 
-	// Make sure things are in order
-	sort.Sort(skills.NewRankedTeams(steams, sranks))
+	// Make sure things are in order; a stable sort keeps tied teams in the
+	// order they were given so results are reproducible
+	rt, err := skills.TryNewRankedTeams(steams, sranks)
+	if err != nil {
+		return nil, err
+	}
+	sort.Stable(rt)
 
 	// Since we verified that each team has one player, we know the player is the first one
 	winningTeam := steams[0]
@@ -38,23 +53,36 @@ func (calc *TwoPlayerCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Te
 
 	wasDraw := sranks[0] == sranks[1]
 
-	newSkills[winner] = twoPlayerCalcNewRating(gi, winnerPrevRating, loserPrevRating, cond(wasDraw, skills.Draw, skills.Win))
-	newSkills[loser] = twoPlayerCalcNewRating(gi, loserPrevRating, winnerPrevRating, cond(wasDraw, skills.Draw, skills.Lose))
+	// Each player's performance is weighted by how much of the match they played
+	winnerWeight := skills.PartialPlayPercentage(winner)
+	loserWeight := skills.PartialPlayPercentage(loser)
 
-	return newSkills
+	// Each player's skill drifts before the match, more so after time away
+	winnerVariance := gi.DynamicVariance(winner, winnerPrevRating)
+	loserVariance := gi.DynamicVariance(loser, loserPrevRating)
+
+	newSkills[winner] = twoPlayerCalcNewRating(gi, winnerPrevRating, loserPrevRating, winnerVariance, loserVariance, winnerWeight, loserWeight, cond(wasDraw, skills.Draw, skills.Win))
+	newSkills[loser] = twoPlayerCalcNewRating(gi, loserPrevRating, winnerPrevRating, loserVariance, winnerVariance, loserWeight, winnerWeight, cond(wasDraw, skills.Draw, skills.Lose))
+
+	skills.ApplyPartialUpdates(teams, newSkills)
+
+	return newSkills, nil
 }
 
-func twoPlayerCalcNewRating(gi *skills.GameInfo, selfRating, oppRating skills.Rating, comparison int) skills.Rating {
-	drawMargin := drawMarginFromDrawProbability(gi.DrawProbability, gi.Beta)
+func twoPlayerCalcNewRating(gi *skills.GameInfo, selfRating, oppRating skills.Rating, varianceWithDynamics, oppVarianceWithDynamics, selfWeight, oppWeight float64, comparison int) skills.Rating {
+	drawMargin := gi.DrawMargin(numerics.Sqr(selfWeight) + numerics.Sqr(oppWeight))
+	betaSqr := numerics.Sqr(gi.Beta)
 
-	c := math.Sqrt(numerics.Sqr(selfRating.Stddev()) + numerics.Sqr(oppRating.Stddev()) + 2*numerics.Sqr(gi.Beta))
+	// The performance difference is spread by the same drifted variances the
+	// update scales by; mixing in the prior variances instead lets a long
+	// absence push the new variance below zero.
+	c := math.Sqrt(numerics.Sqr(selfWeight)*(varianceWithDynamics+betaSqr) + numerics.Sqr(oppWeight)*(oppVarianceWithDynamics+betaSqr))
 
-	winningMean := selfRating.Mean()
-	losingMean := oppRating.Mean()
+	winningMean := selfWeight * selfRating.Mean()
+	losingMean := oppWeight * oppRating.Mean()
 
 	if comparison == skills.Lose {
-		winningMean = oppRating.Mean()
-		losingMean = selfRating.Mean()
+		winningMean, losingMean = losingMean, winningMean
 	}
 
 	meanDelta := winningMean - losingMean
@@ -62,19 +90,17 @@ func twoPlayerCalcNewRating(gi *skills.GameInfo, selfRating, oppRating skills.Ra
 	var v, w, rankMultiplier float64
 
 	if comparison != skills.Draw {
-		v = vExceedsMarginC(meanDelta, drawMargin, c)
-		w = wExceedsMarginC(meanDelta, drawMargin, c)
+		v = numerics.VExceedsMarginC(meanDelta, drawMargin, c)
+		w = numerics.WExceedsMarginC(meanDelta, drawMargin, c)
 		rankMultiplier = float64(comparison)
 	} else {
-		v = vWithinMarginC(meanDelta, drawMargin, c)
-		w = wWithinMarginC(meanDelta, drawMargin, c)
+		v = numerics.VWithinMarginC(meanDelta, drawMargin, c)
+		w = numerics.WWithinMarginC(meanDelta, drawMargin, c)
 		rankMultiplier = 1
 	}
 
-	meanMultiplier := (numerics.Sqr(selfRating.Stddev()) + numerics.Sqr(gi.DynamicsFactor)) / c
-
-	varianceWithDynamics := numerics.Sqr(selfRating.Stddev()) + numerics.Sqr(gi.DynamicsFactor)
-	stdDevMultiplier := varianceWithDynamics / numerics.Sqr(c)
+	meanMultiplier := selfWeight * varianceWithDynamics / c
+	stdDevMultiplier := numerics.Sqr(selfWeight) * varianceWithDynamics / numerics.Sqr(c)
 
 	newMean := selfRating.Mean() + (rankMultiplier * meanMultiplier * v)
 	newStdDev := math.Sqrt(varianceWithDynamics * (1 - w*stdDevMultiplier))
@@ -84,8 +110,19 @@ func twoPlayerCalcNewRating(gi *skills.GameInfo, selfRating, oppRating skills.Ra
 
 // Calculates the match quality as the likelihood of all teams drawing (0% = bad, 100% = well matched).
 func (calc *TwoPlayerCalc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Team) float64 {
-	validateTeamCount(teams, twoPlayerTeamRange)
-	validatePlayersPerTeam(teams, twoPlayerPlayerRange)
+	r, err := calc.TryCalcMatchQual(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcMatchQual but returns an error instead of panicking on invalid input.
+func (calc *TwoPlayerCalc) TryCalcMatchQual(gi *skills.GameInfo, teams []skills.Team) (float64, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, twoPlayerTeamRange, twoPlayerPlayerRange); err != nil {
+		return 0, err
+	}
 
 	team1 := teams[0]
 	p1 := team1.Players()[0]
@@ -95,20 +132,62 @@ func (calc *TwoPlayerCalc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Tea
 	p2 := team2.Players()[0]
 	p2Rating := team2.PlayerRating(p2)
 
+	// Each player's performance is weighted by how much of the match they played
+	w1 := skills.PartialPlayPercentage(p1)
+	w2 := skills.PartialPlayPercentage(p2)
+
 	// We just use equation 4.1 found on page 8 of the TrueSkill 2006 paper:
-	betaSqr := numerics.Sqr(gi.Beta)
-	p1var := p1Rating.Variance()
-	p2var := p2Rating.Variance()
+	betaSqr := numerics.Sqr(gi.Beta) * (numerics.Sqr(w1) + numerics.Sqr(w2))
+	p1var := numerics.Sqr(w1) * p1Rating.Variance()
+	p2var := numerics.Sqr(w2) * p2Rating.Variance()
 
 	// This is the square root part of the equation:
-	sqrtPart := math.Sqrt(2 * betaSqr / (2*betaSqr + p1var + p2var))
+	sqrtPart := math.Sqrt(betaSqr / (betaSqr + p1var + p2var))
 
 	// This is the exponent part of the equation:
-	numerator := -numerics.Sqr(p1Rating.Mean() - p2Rating.Mean())
-	denominator := 2 * (2*betaSqr + p1var + p2var)
+	numerator := -numerics.Sqr(w1*p1Rating.Mean() - w2*p2Rating.Mean())
+	denominator := 2 * (betaSqr + p1var + p2var)
 	expPart := math.Exp(numerator / denominator)
 
-	return sqrtPart * expPart
+	return sqrtPart * expPart, nil
+}
+
+// Predicts the result of a match between exactly two teams.
+func (calc *TwoPlayerCalc) PredictOutcome(gi *skills.GameInfo, teams []skills.Team) skills.Prediction {
+	r, err := calc.TryPredictOutcome(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like PredictOutcome but returns an error instead of panicking on invalid input.
+func (calc *TwoPlayerCalc) TryPredictOutcome(gi *skills.GameInfo, teams []skills.Team) (skills.Prediction, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, predictOutcomeTeamRange, twoPlayerPlayerRange); err != nil {
+		return skills.Prediction{}, err
+	}
+
+	return predictOutcome(gi, teams[0], teams[1]), nil
+}
+
+// Predicts where each team will finish; element [i][k] is the probability that teams[i] finishes in position k+1.
+func (calc *TwoPlayerCalc) PredictRanks(gi *skills.GameInfo, teams []skills.Team) [][]float64 {
+	r, err := calc.TryPredictRanks(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like PredictRanks but returns an error instead of panicking on invalid input.
+func (calc *TwoPlayerCalc) TryPredictRanks(gi *skills.GameInfo, teams []skills.Team) ([][]float64, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, twoPlayerTeamRange, twoPlayerPlayerRange); err != nil {
+		return nil, err
+	}
+
+	return predictRanks(gi, teams), nil
 }
 
 var (
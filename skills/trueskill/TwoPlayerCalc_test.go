@@ -1,12 +1,28 @@
 package trueskill
 
 import (
+	"github.com/ChrisHines/GoSkills/skills"
 	"testing"
 )
 
 func TestTwoPlayerCalc(t *testing.T) {
 	// We only support two players
 	AllTwoPlayerScenarios(t, &TwoPlayerCalc{})
+	OneOnOneHalfPlay(t, &TwoPlayerCalc{})
+	OneOnOnePartialUpdate(t, &TwoPlayerCalc{})
+	AllInvalidInputScenarios(t, &TwoPlayerCalc{})
+	ReturningPlayer(t, &TwoPlayerCalc{})
+	AbsentUpset(t, &TwoPlayerCalc{})
+	AllTwoPlayerPredictionScenarios(t, &TwoPlayerCalc{})
 
-	// TODO: Assert failures for larger teams
+	// Larger teams are rejected
+	team1 := skills.NewTeam()
+	team1.AddPlayer(1, skills.DefaultGameInfo().DefaultRating())
+	team1.AddPlayer(2, skills.DefaultGameInfo().DefaultRating())
+	team2 := skills.NewTeam()
+	team2.AddPlayer(3, skills.DefaultGameInfo().DefaultRating())
+
+	_, err := (&TwoPlayerCalc{}).TryCalcNewRatings(skills.DefaultGameInfo(), []skills.Team{team1, team2}, 1, 2)
+	var e *skills.PlayerCountError
+	AssertErrorAs(t, err, &e)
 }
@@ -13,18 +13,33 @@ type TwoTeamCalc struct{}
 
 // Calculates new ratings based on the prior ratings and team ranks use 1 for first place, repeat the number for a tie (e.g. 1, 2, 2).
 func (calc *TwoTeamCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatings but returns an error instead of panicking on invalid input.
+func (calc *TwoTeamCalc) TryCalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) (skills.PlayerRatings, error) {
 	newSkills := make(map[interface{}]skills.Rating)
 
 	// Basic argument checking
-	validateTeamCount(teams, twoTeamTeamRange)
-	validatePlayersPerTeam(teams, twoTeamPlayerRange)
+	if err := skills.ValidateTeams(gi, teams, twoTeamTeamRange, twoTeamPlayerRange); err != nil {
+		return nil, err
+	}
 
 	// Copy slices so we don't confuse the client code
 	steams := append([]skills.Team{}, teams...)
 	sranks := append([]int{}, ranks...)
 
-	// Make sure things are in order
-	sort.Sort(skills.NewRankedTeams(steams, sranks))
+	// Make sure things are in order; a stable sort keeps tied teams in the
+	// order they were given so results are reproducible
+	rt, err := skills.TryNewRankedTeams(steams, sranks)
+	if err != nil {
+		return nil, err
+	}
+	sort.Stable(rt)
 
 	winningTeam := steams[0]
 	losingTeam := steams[1]
@@ -34,20 +49,22 @@ func (calc *TwoTeamCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team
 	twoTeamUpdateRatings(gi, newSkills, winningTeam, losingTeam, cond(wasDraw, skills.Draw, skills.Win))
 	twoTeamUpdateRatings(gi, newSkills, losingTeam, winningTeam, cond(wasDraw, skills.Draw, skills.Lose))
 
-	return newSkills
+	skills.ApplyPartialUpdates(teams, newSkills)
+
+	return newSkills, nil
 }
 
 func twoTeamUpdateRatings(gi *skills.GameInfo, newSkills skills.PlayerRatings, selfTeam, otherTeam skills.Team, comparison int) {
-	drawMargin := drawMarginFromDrawProbability(gi.DrawProbability, gi.Beta)
 	betaSqr := numerics.Sqr(gi.Beta)
-	tauSqr := numerics.Sqr(gi.DynamicsFactor)
 
-	totalPlayers := selfTeam.PlayerCount() + otherTeam.PlayerCount()
+	// Each player's performance is weighted by how much of the match they
+	// played, so the sums below are weighted too.
+	selfMeanSum, selfVarSum, selfWeightSqrSum := teamDynamicWeightedSums(gi, selfTeam)
+	otherMeanSum, otherVarSum, otherWeightSqrSum := teamDynamicWeightedSums(gi, otherTeam)
 
-	selfMeanSum := selfTeam.Accum(skills.MeanSum)
-	otherMeanSum := otherTeam.Accum(skills.MeanSum)
+	drawMargin := gi.DrawMargin(selfWeightSqrSum + otherWeightSqrSum)
 
-	c := math.Sqrt(selfTeam.Accum(skills.VarianceSum) + otherTeam.Accum(skills.VarianceSum) + float64(totalPlayers)*betaSqr)
+	c := math.Sqrt(selfVarSum + otherVarSum + (selfWeightSqrSum+otherWeightSqrSum)*betaSqr)
 
 	winningMean := selfMeanSum
 	losingMean := otherMeanSum
@@ -61,25 +78,30 @@ func twoTeamUpdateRatings(gi *skills.GameInfo, newSkills skills.PlayerRatings, s
 	var v, w, rankMultiplier float64
 
 	if comparison != skills.Draw {
-		v = vExceedsMarginC(meanDelta, drawMargin, c)
-		w = wExceedsMarginC(meanDelta, drawMargin, c)
+		v = numerics.VExceedsMarginC(meanDelta, drawMargin, c)
+		w = numerics.WExceedsMarginC(meanDelta, drawMargin, c)
 		rankMultiplier = float64(comparison)
 	} else {
-		v = vWithinMarginC(meanDelta, drawMargin, c)
-		w = wWithinMarginC(meanDelta, drawMargin, c)
+		v = numerics.VWithinMarginC(meanDelta, drawMargin, c)
+		w = numerics.WWithinMarginC(meanDelta, drawMargin, c)
 		rankMultiplier = 1
 	}
 
-	for p, r := range selfTeam.PlayerRatings {
+	for _, p := range selfTeam.Players() {
+		r := selfTeam.PlayerRating(p)
 		prevPlayerRating := r
+		weight := skills.PartialPlayPercentage(p)
 
-		meanMultiplier := (prevPlayerRating.Variance() + tauSqr) / c
-		stdDevMultiplier := (prevPlayerRating.Variance() + tauSqr) / numerics.Sqr(c)
+		// The skill drifts before the match, more so after time away
+		varianceWithDynamics := gi.DynamicVariance(p, prevPlayerRating)
+
+		meanMultiplier := weight * varianceWithDynamics / c
+		stdDevMultiplier := weight * weight * varianceWithDynamics / numerics.Sqr(c)
 
 		playerMeanDelta := rankMultiplier * meanMultiplier * v
 		newMean := prevPlayerRating.Mean() + playerMeanDelta
 
-		newStdDev := math.Sqrt((prevPlayerRating.Variance() + tauSqr) * (1 - w*stdDevMultiplier))
+		newStdDev := math.Sqrt(varianceWithDynamics * (1 - w*stdDevMultiplier))
 
 		newSkills[p] = skills.NewRating(newMean, newStdDev)
 	}
@@ -87,37 +109,74 @@ func twoTeamUpdateRatings(gi *skills.GameInfo, newSkills skills.PlayerRatings, s
 
 // Calculates the match quality as the likelihood of all teams drawing (0% = bad, 100% = well matched).
 func (calc *TwoTeamCalc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Team) float64 {
+	r, err := calc.TryCalcMatchQual(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcMatchQual but returns an error instead of panicking on invalid input.
+func (calc *TwoTeamCalc) TryCalcMatchQual(gi *skills.GameInfo, teams []skills.Team) (float64, error) {
 	// Basic argument checking
-	validateTeamCount(teams, twoTeamTeamRange)
-	validatePlayersPerTeam(teams, twoTeamPlayerRange)
+	if err := skills.ValidateTeams(gi, teams, twoTeamTeamRange, twoTeamPlayerRange); err != nil {
+		return 0, err
+	}
 
 	// We've verified that there's just two teams
-	team1 := teams[0]
-	team1Count := team1.PlayerCount()
-
-	team2 := teams[1]
-	team2Count := team2.PlayerCount()
-
-	totalPlayers := team1Count + team2Count
+	team1MeanSum, team1VarSum, team1WeightSqrSum := teamWeightedSums(teams[0])
+	team2MeanSum, team2VarSum, team2WeightSqrSum := teamWeightedSums(teams[1])
 
 	betaSqr := numerics.Sqr(gi.Beta)
 
-	team1MeanSum := team1.Accum(skills.MeanSum)
-	team1VarSum := team1.Accum(skills.VarianceSum)
-
-	team2MeanSum := team2.Accum(skills.MeanSum)
-	team2VarSum := team2.Accum(skills.VarianceSum)
-
 	// This comes from equation 4.1 in the TrueSkill paper on page 8
 	// The equation was broken up into the part under the square root sign and
 	// the exponential part to make the code easier to read.
 
-	betaSqrPlayers := betaSqr * float64(totalPlayers)
+	betaSqrPlayers := betaSqr * (team1WeightSqrSum + team2WeightSqrSum)
 
 	sqrtPart := math.Sqrt(betaSqrPlayers / (betaSqrPlayers + team1VarSum + team2VarSum))
 	expPart := math.Exp(-.5 * numerics.Sqr(team1MeanSum-team2MeanSum) / (betaSqrPlayers + team1VarSum + team2VarSum))
 
-	return expPart * sqrtPart
+	return expPart * sqrtPart, nil
+}
+
+// Predicts the result of a match between exactly two teams.
+func (calc *TwoTeamCalc) PredictOutcome(gi *skills.GameInfo, teams []skills.Team) skills.Prediction {
+	r, err := calc.TryPredictOutcome(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like PredictOutcome but returns an error instead of panicking on invalid input.
+func (calc *TwoTeamCalc) TryPredictOutcome(gi *skills.GameInfo, teams []skills.Team) (skills.Prediction, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, predictOutcomeTeamRange, twoTeamPlayerRange); err != nil {
+		return skills.Prediction{}, err
+	}
+
+	return predictOutcome(gi, teams[0], teams[1]), nil
+}
+
+// Predicts where each team will finish; element [i][k] is the probability that teams[i] finishes in position k+1.
+func (calc *TwoTeamCalc) PredictRanks(gi *skills.GameInfo, teams []skills.Team) [][]float64 {
+	r, err := calc.TryPredictRanks(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like PredictRanks but returns an error instead of panicking on invalid input.
+func (calc *TwoTeamCalc) TryPredictRanks(gi *skills.GameInfo, teams []skills.Team) ([][]float64, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, twoTeamTeamRange, twoTeamPlayerRange); err != nil {
+		return nil, err
+	}
+
+	return predictRanks(gi, teams), nil
 }
 
 var (
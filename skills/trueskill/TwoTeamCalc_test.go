@@ -7,4 +7,13 @@ import (
 func TestTwoTeamCalc(t *testing.T) {
 	AllTwoPlayerScenarios(t, &TwoTeamCalc{})
 	AllTwoTeamScenarios(t, &TwoTeamCalc{})
+	AllPartialPlayScenarios(t, &TwoTeamCalc{})
+	AllPartialUpdateScenarios(t, &TwoTeamCalc{})
+	AllInvalidInputScenarios(t, &TwoTeamCalc{})
+	ReturningPlayer(t, &TwoTeamCalc{})
+	AbsentUpset(t, &TwoTeamCalc{})
+	TeamSizeDrawMarginUpdates(t, &TwoTeamCalc{})
+	TeamSizeDrawProbability(t, &TwoTeamCalc{})
+	AllTwoPlayerPredictionScenarios(t, &TwoTeamCalc{})
+	ReproducibleResults(t, &TwoTeamCalc{})
 }
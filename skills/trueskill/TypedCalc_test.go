@@ -0,0 +1,35 @@
+package trueskill
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"testing"
+)
+
+func TestTypedCalc(t *testing.T) {
+	gameInfo := skills.DefaultGameInfo()
+
+	for _, calc := range []skills.CalcOf[string]{
+		skills.NewCalcOf[string](&TwoPlayerCalc{}),
+		skills.NewCalcOf[string](&TwoTeamCalc{}),
+		skills.NewCalcOf[string](&FactorGraphCalc{}),
+	} {
+		alice := skills.NewTeamOf[string]()
+		alice.AddPlayer("alice", gameInfo.DefaultRating())
+
+		bob := skills.NewTeamOf[string]()
+		bob.AddPlayer("bob", gameInfo.DefaultRating())
+
+		teams := []skills.TeamOf[string]{alice, bob}
+
+		newRatings := calc.CalcNewRatings(gameInfo, teams, 1, 2)
+
+		AssertRating(t, 29.39583201999924, 7.171475587326186, newRatings["alice"])
+		AssertRating(t, 20.60416798000076, 7.171475587326186, newRatings["bob"])
+
+		AssertMatchQuality(t, 0.447, calc.CalcMatchQual(gameInfo, teams))
+
+		_, err := calc.TryCalcNewRatings(gameInfo, teams, 1)
+		var e *skills.RankCountError
+		AssertErrorAs(t, err, &e)
+	}
+}
@@ -1,23 +1,42 @@
 package trueskill
 
 import (
-	"fmt"
 	"github.com/ChrisHines/GoSkills/skills"
-	"github.com/ChrisHines/GoSkills/skills/numerics"
 )
 
-func validateTeamCount(teams []skills.Team, teamsAllowed numerics.Range) {
-	if n := len(teams); !teamsAllowed.In(n) {
-		panic(fmt.Errorf("len(teams) [%v] outside of expected range [%v]", n, teamsAllowed))
+// Returns the partial play weighted sums of a team's means and variances,
+// along with the sum of the squared weights.
+func teamWeightedSums(t skills.Team) (meanSum, varSum, weightSqrSum float64) {
+	for _, p := range t.Players() {
+		r := t.PlayerRating(p)
+		w := skills.PartialPlayPercentage(p)
+		meanSum += w * r.Mean()
+		varSum += w * w * r.Variance()
+		weightSqrSum += w * w
 	}
+	return
 }
 
-func validatePlayersPerTeam(teams []skills.Team, playersAllowed numerics.Range) {
-	for _, t := range teams {
-		if n := t.PlayerCount(); !playersAllowed.In(n) {
-			panic(fmt.Errorf("PlayerCount [%v] outside of expected range [%v]", n, playersAllowed))
-		}
+// Like teamWeightedSums but with each player's variance drifted by the skill
+// dynamics before the match, as the rating updates use.
+func teamDynamicWeightedSums(gi *skills.GameInfo, t skills.Team) (meanSum, varSum, weightSqrSum float64) {
+	for _, p := range t.Players() {
+		r := t.PlayerRating(p)
+		w := skills.PartialPlayPercentage(p)
+		meanSum += w * r.Mean()
+		varSum += w * w * gi.DynamicVariance(p, r)
+		weightSqrSum += w * w
 	}
+	return
+}
+
+// Returns a copy of gi without skill dynamics, for rating players whose
+// ratings have already drifted.
+func withoutDynamics(gi *skills.GameInfo) *skills.GameInfo {
+	static := *gi
+	static.DynamicsFactor = 0
+	static.DynamicsPerTime = 0
+	return &static
 }
 
 func cond(c bool, t, f int) int {
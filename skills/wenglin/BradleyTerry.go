@@ -0,0 +1,86 @@
+package wenglin
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+)
+
+// The Bradley-Terry model compares teams with a logistic curve.
+func bradleyTerryPair(gi *skills.GameInfo, i, q *team) (omega, delta float64) {
+	c := pairC(gi, i, q)
+	pIQ := bradleyTerryWinProb(gi, i, q)
+	gamma := math.Sqrt(i.variance) / c
+
+	omega = i.variance / c * (score(i, q) - pIQ)
+	delta = gamma * i.variance / (c * c) * pIQ * (1 - pIQ)
+	return
+}
+
+func bradleyTerryWinProb(gi *skills.GameInfo, i, q *team) float64 {
+	return 1 / (1 + math.Exp((q.mean-i.mean)/pairC(gi, i, q)))
+}
+
+// Calculates ratings with the Bradley-Terry model, comparing every pair of
+// teams. It supports any number of teams with one or more players each.
+type BradleyTerryFullCalc struct{}
+
+// Calculates new ratings based on the prior ratings and team ranks use 1 for first place, repeat the number for a tie (e.g. 1, 2, 2).
+func (calc *BradleyTerryFullCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatings but returns an error instead of panicking on invalid input.
+func (calc *BradleyTerryFullCalc) TryCalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) (skills.PlayerRatings, error) {
+	return calcNewRatings(gi, teams, ranks, fullPairing(bradleyTerryPair))
+}
+
+// Calculates the match quality as the closeness of the worst matched pair of teams to an even match.
+func (calc *BradleyTerryFullCalc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Team) float64 {
+	r, err := calc.TryCalcMatchQual(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcMatchQual but returns an error instead of panicking on invalid input.
+func (calc *BradleyTerryFullCalc) TryCalcMatchQual(gi *skills.GameInfo, teams []skills.Team) (float64, error) {
+	return calcMatchQual(gi, teams, bradleyTerryWinProb)
+}
+
+// Calculates ratings with the Bradley-Terry model, comparing each team only
+// with its neighbors in the ranking. It supports any number of teams with one
+// or more players each.
+type BradleyTerryPartCalc struct{}
+
+// Calculates new ratings based on the prior ratings and team ranks use 1 for first place, repeat the number for a tie (e.g. 1, 2, 2).
+func (calc *BradleyTerryPartCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatings but returns an error instead of panicking on invalid input.
+func (calc *BradleyTerryPartCalc) TryCalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) (skills.PlayerRatings, error) {
+	return calcNewRatings(gi, teams, ranks, partPairing(bradleyTerryPair))
+}
+
+// Calculates the match quality as the closeness of the worst matched pair of teams to an even match.
+func (calc *BradleyTerryPartCalc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Team) float64 {
+	r, err := calc.TryCalcMatchQual(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcMatchQual but returns an error instead of panicking on invalid input.
+func (calc *BradleyTerryPartCalc) TryCalcMatchQual(gi *skills.GameInfo, teams []skills.Team) (float64, error) {
+	return calcMatchQual(gi, teams, bradleyTerryWinProb)
+}
@@ -0,0 +1,29 @@
+package wenglin
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"testing"
+)
+
+func TestBradleyTerry(t *testing.T) {
+	// With two players both variants match the OpenSkill reference values
+	for _, calc := range []calculator{&BradleyTerryFullCalc{}, &BradleyTerryPartCalc{}} {
+		gi := staticGameInfo()
+		newRatings := calc.CalcNewRatings(gi, teamsOfOne(gi, 25, 25), 1, 2)
+
+		assertNear(t, "winner mean", newRatings[0].Mean(), 27.635)
+		assertNear(t, "winner stddev", newRatings[0].Stddev(), 8.066)
+		assertNear(t, "loser mean", newRatings[1].Mean(), 22.365)
+		assertNear(t, "loser stddev", newRatings[1].Stddev(), 8.066)
+	}
+}
+
+func TestBradleyTerryPartIgnoresDistantRanks(t *testing.T) {
+	gi := skills.DefaultGameInfo()
+	calc := &BradleyTerryPartCalc{}
+
+	// Only the neighbors of the middle player matter to them
+	a := calc.CalcNewRatings(gi, teamsOfOne(gi, 25, 25, 25, 25), 1, 2, 3, 4)
+	b := calc.CalcNewRatings(gi, teamsOfOne(gi, 25, 25, 25, 40), 1, 2, 3, 4)
+	assertNear(t, "player 1 mean", b[1].Mean(), a[1].Mean())
+}
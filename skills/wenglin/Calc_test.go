@@ -0,0 +1,223 @@
+package wenglin
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ChrisHines/GoSkills/skills"
+	"math"
+	"runtime"
+	"testing"
+)
+
+const errorTolerance = 0.001
+
+type calculator interface {
+	skills.Calc
+	skills.TryCalc
+}
+
+func allCalcs() map[string]calculator {
+	return map[string]calculator{
+		"BradleyTerryFull":       &BradleyTerryFullCalc{},
+		"BradleyTerryPart":       &BradleyTerryPartCalc{},
+		"ThurstoneMostellerFull": &ThurstoneMostellerFullCalc{},
+		"ThurstoneMostellerPart": &ThurstoneMostellerPartCalc{},
+		"PlackettLuce":           &PlackettLuceCalc{},
+	}
+}
+
+func TestAllCalcs(t *testing.T) {
+	for name, calc := range allCalcs() {
+		t.Run(name, func(t *testing.T) {
+			OneOnOne(t, calc)
+			OneOnOneDraw(t, calc)
+			FreeForAll(t, calc)
+			TwoOnTwo(t, calc)
+			PartialPlay(t, calc)
+			ReturningPlayer(t, calc)
+			MatchQual(t, calc)
+			InvalidInput(t, calc)
+		})
+	}
+}
+
+func OneOnOne(t *testing.T, calc skills.Calc) {
+	gi := skills.DefaultGameInfo()
+	teams := teamsOfOne(gi, 25, 25)
+
+	newRatings := calc.CalcNewRatings(gi, teams, 1, 2)
+
+	winner, loser := newRatings[0], newRatings[1]
+	if winner.Mean() <= 25 || loser.Mean() >= 25 {
+		t.Errorf("means = %v, %v; want winner above 25 and loser below\n%v", winner.Mean(), loser.Mean(), testLoc())
+	}
+	assertNear(t, "mean sum", winner.Mean()+loser.Mean(), 50)
+	assertNear(t, "loser stddev", loser.Stddev(), winner.Stddev())
+	if winner.Stddev() >= gi.InitialStddev {
+		t.Errorf("stddev = %v, want less than %v\n%v", winner.Stddev(), gi.InitialStddev, testLoc())
+	}
+}
+
+func OneOnOneDraw(t *testing.T, calc skills.Calc) {
+	gi := skills.DefaultGameInfo()
+	teams := teamsOfOne(gi, 25, 25)
+
+	newRatings := calc.CalcNewRatings(gi, teams, 1, 1)
+
+	assertNear(t, "player 1 mean", newRatings[0].Mean(), 25)
+	assertNear(t, "player 2 mean", newRatings[1].Mean(), 25)
+}
+
+func FreeForAll(t *testing.T, calc skills.Calc) {
+	gi := skills.DefaultGameInfo()
+	teams := teamsOfOne(gi, 25, 25, 25, 25)
+
+	// Give the ranks out of order to check the calculator sorts them
+	newRatings := calc.CalcNewRatings(gi, teams, 3, 1, 4, 2)
+
+	// The partial pairing leaves evenly matched middle places unchanged, so
+	// only require the means not to rise with place
+	order := []int{1, 3, 0, 2}
+	for i := 1; i < len(order); i++ {
+		better, worse := newRatings[order[i-1]].Mean(), newRatings[order[i]].Mean()
+		if better < worse-errorTolerance {
+			t.Errorf("player %v mean %v below player %v mean %v\n%v", order[i-1], better, order[i], worse, testLoc())
+		}
+	}
+	if newRatings[1].Mean() <= 25 || newRatings[2].Mean() >= 25 {
+		t.Errorf("means = %v, %v; want winner above 25 and last place below\n%v", newRatings[1].Mean(), newRatings[2].Mean(), testLoc())
+	}
+}
+
+func TwoOnTwo(t *testing.T, calc skills.Calc) {
+	gi := skills.DefaultGameInfo()
+	team1 := skills.NewTeam()
+	team1.AddPlayer(0, gi.DefaultRating())
+	team1.AddPlayer(1, skills.NewRating(30, 2))
+	team2 := skills.NewTeam()
+	team2.AddPlayer(2, gi.DefaultRating())
+	team2.AddPlayer(3, gi.DefaultRating())
+
+	newRatings := calc.CalcNewRatings(gi, []skills.Team{team1, team2}, 1, 2)
+
+	// The more certain player moves less
+	if d0, d1 := newRatings[0].Mean()-25, newRatings[1].Mean()-30; d0 <= d1 || d1 <= 0 {
+		t.Errorf("mean changes = %v, %v; want both positive and the first larger\n%v", d0, d1, testLoc())
+	}
+	assertNear(t, "team 2 means", newRatings[2].Mean(), newRatings[3].Mean())
+}
+
+func PartialPlay(t *testing.T, calc skills.Calc) {
+	gi := skills.DefaultGameInfo()
+	full, half := skills.NewPlayer(0), skills.NewPartialPlayer(1, 0.5)
+	team1 := skills.NewTeam()
+	team1.AddPlayer(full, gi.DefaultRating())
+	team1.AddPlayer(half, gi.DefaultRating())
+	team2 := skills.NewTeam()
+	team2.AddPlayer(2, gi.DefaultRating())
+
+	newRatings := calc.CalcNewRatings(gi, []skills.Team{team1, team2}, 1, 2)
+
+	if f, h := newRatings[full].Mean()-25, newRatings[half].Mean()-25; h >= f || h <= 0 {
+		t.Errorf("mean changes = %v, %v; want the partial player to move less\n%v", f, h, testLoc())
+	}
+}
+
+type awayPlayer struct {
+	id      int
+	elapsed float64
+}
+
+func (p awayPlayer) ElapsedTime() float64 { return p.elapsed }
+
+// A player back from a break is less certain, so the same result moves their
+// rating further, but never from further away than a new player's.
+func ReturningPlayer(t *testing.T, calc skills.Calc) {
+	gi := skills.NewGameInfo(skills.WithDynamicsPerTime(0.2))
+	rate := func(p awayPlayer) skills.Rating {
+		team1, team2 := skills.NewTeam(), skills.NewTeam()
+		team1.AddPlayer(p, skills.NewRating(30, 2))
+		team2.AddPlayer(2, gi.DefaultRating())
+		return calc.CalcNewRatings(gi, []skills.Team{team1, team2}, 2, 1)[p]
+	}
+
+	regular := rate(awayPlayer{1, 0})
+	returning := rate(awayPlayer{1, 3650})
+	if returning.Mean() >= regular.Mean() {
+		t.Errorf("returning mean = %v, want less than %v\n%v", returning.Mean(), regular.Mean(), testLoc())
+	}
+
+	// The drift is capped at InitialStddev, so a much longer break is the same
+	if longer := rate(awayPlayer{1, 3650000}); longer != returning {
+		t.Errorf("rating after a longer break = %v, want %v\n%v", longer, returning, testLoc())
+	}
+}
+
+func MatchQual(t *testing.T, calc skills.Calc) {
+	gi := skills.DefaultGameInfo()
+
+	assertNear(t, "even match quality", calc.CalcMatchQual(gi, teamsOfOne(gi, 25, 25, 25)), 1)
+
+	uneven := calc.CalcMatchQual(gi, teamsOfOne(gi, 25, 25, 40))
+	if uneven <= 0 || uneven >= calc.CalcMatchQual(gi, teamsOfOne(gi, 25, 30)) {
+		t.Errorf("match quality = %v, want a worse mismatch to lower quality\n%v", uneven, testLoc())
+	}
+}
+
+func InvalidInput(t *testing.T, calc skills.TryCalc) {
+	gi := skills.DefaultGameInfo()
+
+	_, err := calc.TryCalcNewRatings(gi, teamsOfOne(gi, 25), 1)
+	var teamErr *skills.TeamCountError
+	assertErrorAs(t, err, &teamErr)
+
+	teams := append(teamsOfOne(gi, 25), skills.NewTeam())
+	_, err = calc.TryCalcNewRatings(gi, teams, 1, 2)
+	var playerErr *skills.PlayerCountError
+	assertErrorAs(t, err, &playerErr)
+
+	_, err = calc.TryCalcMatchQual(gi, teams)
+	assertErrorAs(t, err, &playerErr)
+
+	_, err = calc.TryCalcNewRatings(gi, teamsOfOne(gi, 25, 25), 1)
+	var rankErr *skills.RankCountError
+	assertErrorAs(t, err, &rankErr)
+}
+
+// Returns one team per mean with the player's id the index of the mean.
+func teamsOfOne(gi *skills.GameInfo, means ...float64) []skills.Team {
+	teams := make([]skills.Team, len(means))
+	for i, m := range means {
+		teams[i] = skills.NewTeam()
+		teams[i].AddPlayer(i, skills.NewRating(m, gi.InitialStddev))
+	}
+	return teams
+}
+
+// Returns DefaultGameInfo() without the dynamics factor, which the
+// published OpenSkill examples don't use.
+func staticGameInfo() *skills.GameInfo {
+	gi := *skills.DefaultGameInfo()
+	gi.DynamicsFactor = 0
+	return &gi
+}
+
+func assertNear(t *testing.T, what string, got, want float64) {
+	if math.Abs(got-want) > errorTolerance {
+		t.Errorf("%v = %v, want %v\n%v", what, got, want, testLoc())
+	}
+}
+
+func assertErrorAs(t *testing.T, err error, target interface{}) {
+	if !errors.As(err, target) {
+		t.Errorf("err = %v, want %T\n%v", err, target, testLoc())
+	}
+}
+
+func testLoc() string {
+	_, file, line, ok := runtime.Caller(2)
+	if ok {
+		return fmt.Sprintf("%v:%v", file, line)
+	}
+	return ""
+}
@@ -0,0 +1,90 @@
+package wenglin
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+)
+
+// The Plackett-Luce model treats the ranking as a sequence of choices: the
+// winner is chosen from all teams, second place from the rest and so on. Tied
+// teams share their place.
+func plackettLuce(gi *skills.GameInfo, teams []*team) (omegas, deltas []float64) {
+	betaSqr := numerics.Sqr(gi.Beta)
+	cSqr := 0.0
+	for _, t := range teams {
+		cSqr += t.variance + betaSqr
+	}
+	c := math.Sqrt(cSqr)
+
+	// sums[q] is the total strength of the teams still to be chosen when
+	// team q's place is chosen, and ties[q] the number of teams sharing it.
+	sums := make([]float64, len(teams))
+	ties := make([]float64, len(teams))
+	for q, tq := range teams {
+		for _, ts := range teams {
+			if ts.rank >= tq.rank {
+				sums[q] += math.Exp(ts.mean / c)
+			}
+			if ts.rank == tq.rank {
+				ties[q]++
+			}
+		}
+	}
+
+	omegas = make([]float64, len(teams))
+	deltas = make([]float64, len(teams))
+	for i, ti := range teams {
+		strength := math.Exp(ti.mean / c)
+		for q, tq := range teams {
+			if tq.rank > ti.rank {
+				continue
+			}
+			p := strength / sums[q]
+			deltas[i] += p * (1 - p) / ties[q]
+			if q == i {
+				omegas[i] += (1 - p) / ties[q]
+			} else {
+				omegas[i] -= p / ties[q]
+			}
+		}
+
+		gamma := math.Sqrt(ti.variance) / c
+		omegas[i] *= ti.variance / c
+		deltas[i] *= gamma * ti.variance / cSqr
+	}
+
+	return
+}
+
+// Calculates ratings with the Plackett-Luce model. It supports any number of
+// teams with one or more players each.
+type PlackettLuceCalc struct{}
+
+// Calculates new ratings based on the prior ratings and team ranks use 1 for first place, repeat the number for a tie (e.g. 1, 2, 2).
+func (calc *PlackettLuceCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatings but returns an error instead of panicking on invalid input.
+func (calc *PlackettLuceCalc) TryCalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) (skills.PlayerRatings, error) {
+	return calcNewRatings(gi, teams, ranks, plackettLuce)
+}
+
+// Calculates the match quality as the closeness of the worst matched pair of teams to an even match.
+func (calc *PlackettLuceCalc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Team) float64 {
+	r, err := calc.TryCalcMatchQual(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcMatchQual but returns an error instead of panicking on invalid input.
+func (calc *PlackettLuceCalc) TryCalcMatchQual(gi *skills.GameInfo, teams []skills.Team) (float64, error) {
+	return calcMatchQual(gi, teams, bradleyTerryWinProb)
+}
@@ -0,0 +1,17 @@
+package wenglin
+
+import (
+	"testing"
+)
+
+func TestPlackettLuce(t *testing.T) {
+	gi := staticGameInfo()
+	calc := &PlackettLuceCalc{}
+
+	// The OpenSkill reference values
+	newRatings := calc.CalcNewRatings(gi, teamsOfOne(gi, 25, 25), 1, 2)
+	assertNear(t, "winner mean", newRatings[0].Mean(), 27.635)
+	assertNear(t, "winner stddev", newRatings[0].Stddev(), 8.066)
+	assertNear(t, "loser mean", newRatings[1].Mean(), 22.365)
+	assertNear(t, "loser stddev", newRatings[1].Stddev(), 8.066)
+}
@@ -0,0 +1,99 @@
+package wenglin
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+)
+
+// The Thurstone-Mosteller model compares teams with a Gaussian curve and a
+// draw margin, like TrueSkill.
+func thurstoneMostellerPair(gi *skills.GameInfo, i, q *team) (omega, delta float64) {
+	c := pairC(gi, i, q)
+	t := (i.mean - q.mean) / c
+	e := gi.DrawMargin(i.weightSqrSum+q.weightSqrSum) / c
+	gamma := math.Sqrt(i.variance) / c
+
+	var v, w float64
+	switch score(i, q) {
+	case 1:
+		v, w = numerics.VExceedsMargin(t, e), numerics.WExceedsMargin(t, e)
+	case 0:
+		v, w = -numerics.VExceedsMargin(-t, e), numerics.WExceedsMargin(-t, e)
+	default:
+		v, w = numerics.VWithinMargin(t, e), numerics.WWithinMargin(t, e)
+	}
+
+	omega = i.variance / c * v
+	delta = gamma * i.variance / (c * c) * w
+	return
+}
+
+func thurstoneMostellerWinProb(gi *skills.GameInfo, i, q *team) float64 {
+	return numerics.GaussCumulativeTo((i.mean - q.mean) / pairC(gi, i, q))
+}
+
+// Calculates ratings with the Thurstone-Mosteller model, comparing every pair
+// of teams. It supports any number of teams with one or more players each.
+type ThurstoneMostellerFullCalc struct{}
+
+// Calculates new ratings based on the prior ratings and team ranks use 1 for first place, repeat the number for a tie (e.g. 1, 2, 2).
+func (calc *ThurstoneMostellerFullCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatings but returns an error instead of panicking on invalid input.
+func (calc *ThurstoneMostellerFullCalc) TryCalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) (skills.PlayerRatings, error) {
+	return calcNewRatings(gi, teams, ranks, fullPairing(thurstoneMostellerPair))
+}
+
+// Calculates the match quality as the closeness of the worst matched pair of teams to an even match.
+func (calc *ThurstoneMostellerFullCalc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Team) float64 {
+	r, err := calc.TryCalcMatchQual(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcMatchQual but returns an error instead of panicking on invalid input.
+func (calc *ThurstoneMostellerFullCalc) TryCalcMatchQual(gi *skills.GameInfo, teams []skills.Team) (float64, error) {
+	return calcMatchQual(gi, teams, thurstoneMostellerWinProb)
+}
+
+// Calculates ratings with the Thurstone-Mosteller model, comparing each team
+// only with its neighbors in the ranking. It supports any number of teams with
+// one or more players each.
+type ThurstoneMostellerPartCalc struct{}
+
+// Calculates new ratings based on the prior ratings and team ranks use 1 for first place, repeat the number for a tie (e.g. 1, 2, 2).
+func (calc *ThurstoneMostellerPartCalc) CalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) skills.PlayerRatings {
+	r, err := calc.TryCalcNewRatings(gi, teams, ranks...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcNewRatings but returns an error instead of panicking on invalid input.
+func (calc *ThurstoneMostellerPartCalc) TryCalcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks ...int) (skills.PlayerRatings, error) {
+	return calcNewRatings(gi, teams, ranks, partPairing(thurstoneMostellerPair))
+}
+
+// Calculates the match quality as the closeness of the worst matched pair of teams to an even match.
+func (calc *ThurstoneMostellerPartCalc) CalcMatchQual(gi *skills.GameInfo, teams []skills.Team) float64 {
+	r, err := calc.TryCalcMatchQual(gi, teams)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Like CalcMatchQual but returns an error instead of panicking on invalid input.
+func (calc *ThurstoneMostellerPartCalc) TryCalcMatchQual(gi *skills.GameInfo, teams []skills.Team) (float64, error) {
+	return calcMatchQual(gi, teams, thurstoneMostellerWinProb)
+}
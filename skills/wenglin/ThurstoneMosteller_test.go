@@ -0,0 +1,40 @@
+package wenglin
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"testing"
+)
+
+func TestThurstoneMosteller(t *testing.T) {
+	// For two players the mean update is the same as TrueSkill's
+	for _, calc := range []calculator{&ThurstoneMostellerFullCalc{}, &ThurstoneMostellerPartCalc{}} {
+		gi := skills.DefaultGameInfo()
+		newRatings := calc.CalcNewRatings(gi, teamsOfOne(gi, 25, 25), 1, 2)
+
+		assertNear(t, "winner mean", newRatings[0].Mean(), 29.396)
+		assertNear(t, "loser mean", newRatings[1].Mean(), 20.604)
+	}
+}
+
+func TestThurstoneMostellerTeamSizeDrawMargin(t *testing.T) {
+	calc := &ThurstoneMostellerFullCalc{}
+	legacy, sized := skills.DefaultGameInfo(), skills.NewGameInfo(skills.WithTeamSizeDrawMargin(true))
+
+	// Two players have the same margin either way
+	if a, b := calc.CalcNewRatings(legacy, teamsOfOne(legacy, 25, 30), 1, 1), calc.CalcNewRatings(sized, teamsOfOne(sized, 25, 30), 1, 1); a[0] != b[0] {
+		t.Errorf("1v1 draw = %v with the team size margin, want %v", b[0], a[0])
+	}
+
+	// Bigger teams draw more easily, so a draw says less about them
+	twoOnTwo := func(gi *skills.GameInfo) skills.PlayerRatings {
+		team1, team2 := skills.NewTeam(), skills.NewTeam()
+		team1.AddPlayer(0, skills.NewRating(25, 8))
+		team1.AddPlayer(1, skills.NewRating(25, 8))
+		team2.AddPlayer(2, skills.NewRating(30, 8))
+		team2.AddPlayer(3, skills.NewRating(30, 8))
+		return calc.CalcNewRatings(gi, []skills.Team{team1, team2}, 1, 1)
+	}
+	if a, b := twoOnTwo(legacy), twoOnTwo(sized); b[0].Mean()-25 >= a[0].Mean()-25 {
+		t.Errorf("2v2 draw mean change = %v with the team size margin, want less than %v", b[0].Mean()-25, a[0].Mean()-25)
+	}
+}
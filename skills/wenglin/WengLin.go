@@ -0,0 +1,183 @@
+package wenglin
+
+import (
+	"github.com/ChrisHines/GoSkills/skills"
+	"github.com/ChrisHines/GoSkills/skills/numerics"
+	"math"
+	"sort"
+)
+
+// The models from Weng and Lin, "A Bayesian Approximation Method for Online
+// Ranking" (JMLR 2011), the basis of OpenSkill. Each model computes, for every
+// team, Ω (the mean update) and Δ (the variance reduction) in closed form, so
+// no factor graph is needed.
+//
+// Ratings use the same mean and standard deviation as TrueSkill, and the
+// models use GameInfo the same way: Beta for performance noise,
+// DynamicVariance for the drift before a match and, in Thurstone-Mosteller,
+// DrawMargin for draws.
+
+// Keeps variances positive when a team's Δ would remove all of a player's
+// uncertainty.
+const kappa = 0.0001
+
+// A team's players and their combined performance.
+type team struct {
+	players   []interface{}
+	weights   []float64 // partial play weights
+	variances []float64 // player variances including the dynamics
+	mean      float64
+	variance  float64
+	rank      int
+
+	weightSqrSum float64 // the sum of the squared partial play weights
+}
+
+// Returns Ω and Δ for every team, with teams sorted by rank.
+type updater func(gi *skills.GameInfo, teams []*team) (omegas, deltas []float64)
+
+// Returns the probability that team t1 beats team t2.
+type winProbFunc func(gi *skills.GameInfo, t1, t2 *team) float64
+
+func calcNewRatings(gi *skills.GameInfo, teams []skills.Team, ranks []int, update updater) (skills.PlayerRatings, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, wengLinTeamRange, wengLinPlayerRange); err != nil {
+		return nil, err
+	}
+
+	// Copy the slices so we don't confuse the client code
+	steams := append([]skills.Team{}, teams...)
+	sranks := append([]int{}, ranks...)
+
+	// Make sure things are in order; a stable sort keeps tied teams in the
+	// order they were given so results are reproducible
+	rt, err := skills.TryNewRankedTeams(steams, sranks)
+	if err != nil {
+		return nil, err
+	}
+	sort.Stable(rt)
+
+	ts := newTeams(gi, steams, sranks)
+	omegas, deltas := update(gi, ts)
+
+	newSkills := make(skills.PlayerRatings)
+	for i, t := range ts {
+		for j, p := range t.players {
+			w, pVar := t.weights[j], t.variances[j]
+			r := steams[i].PlayerRating(p)
+
+			newMean := r.Mean() + w*pVar/t.variance*omegas[i]
+			newVar := pVar * math.Max(1-w*w*pVar/t.variance*deltas[i], kappa)
+
+			newSkills[p] = skills.NewRating(newMean, math.Sqrt(newVar))
+		}
+	}
+
+	skills.ApplyPartialUpdates(teams, newSkills)
+
+	return newSkills, nil
+}
+
+// Calculates the match quality as the closeness of the worst matched pair of
+// teams' win probability to 50%.
+func calcMatchQual(gi *skills.GameInfo, teams []skills.Team, winProb winProbFunc) (float64, error) {
+	// Basic argument checking
+	if err := skills.ValidateTeams(gi, teams, wengLinTeamRange, wengLinPlayerRange); err != nil {
+		return 0, err
+	}
+
+	ts := newTeams(gi, teams, make([]int, len(teams)))
+
+	minQual := 1.0
+	for i := range ts {
+		for q := i + 1; q < len(ts); q++ {
+			minQual = math.Min(minQual, 1-math.Abs(2*winProb(gi, ts[i], ts[q])-1))
+		}
+	}
+
+	return minQual, nil
+}
+
+func newTeams(gi *skills.GameInfo, teams []skills.Team, ranks []int) []*team {
+	ts := make([]*team, len(teams))
+	for i, st := range teams {
+		t := &team{rank: ranks[i]}
+		for _, p := range st.Players() {
+			r := st.PlayerRating(p)
+			w := skills.PartialPlayPercentage(p)
+			pVar := gi.DynamicVariance(p, r)
+
+			t.players = append(t.players, p)
+			t.weights = append(t.weights, w)
+			t.variances = append(t.variances, pVar)
+			t.mean += w * r.Mean()
+			t.variance += w * w * pVar
+			t.weightSqrSum += w * w
+		}
+		ts[i] = t
+	}
+	return ts
+}
+
+// Returns Ω and Δ contributed to team i by comparing it with team q.
+type pairUpdate func(gi *skills.GameInfo, i, q *team) (omega, delta float64)
+
+// Compares every team with every other team.
+func fullPairing(pair pairUpdate) updater {
+	return func(gi *skills.GameInfo, teams []*team) (omegas, deltas []float64) {
+		omegas = make([]float64, len(teams))
+		deltas = make([]float64, len(teams))
+		for i := range teams {
+			for q := range teams {
+				if q == i {
+					continue
+				}
+				o, d := pair(gi, teams[i], teams[q])
+				omegas[i] += o
+				deltas[i] += d
+			}
+		}
+		return
+	}
+}
+
+// Compares each team only with the teams ranked just above and below it,
+// which is cheaper for large free-for-alls.
+func partPairing(pair pairUpdate) updater {
+	return func(gi *skills.GameInfo, teams []*team) (omegas, deltas []float64) {
+		omegas = make([]float64, len(teams))
+		deltas = make([]float64, len(teams))
+		for i := range teams {
+			for _, q := range []int{i - 1, i + 1} {
+				if q < 0 || q >= len(teams) {
+					continue
+				}
+				o, d := pair(gi, teams[i], teams[q])
+				omegas[i] += o
+				deltas[i] += d
+			}
+		}
+		return
+	}
+}
+
+// Returns 1 if team i beat team q, 0.5 for a draw and 0 for a loss.
+func score(i, q *team) float64 {
+	switch {
+	case i.rank < q.rank:
+		return 1
+	case i.rank > q.rank:
+		return 0
+	}
+	return 0.5
+}
+
+// The spread of the difference between two teams' performances.
+func pairC(gi *skills.GameInfo, i, q *team) float64 {
+	return math.Sqrt(i.variance + q.variance + 2*numerics.Sqr(gi.Beta))
+}
+
+var (
+	wengLinTeamRange   = numerics.AtLeast(2)
+	wengLinPlayerRange = numerics.AtLeast(1)
+)